@@ -32,7 +32,7 @@ func (cc *Client) GetAccountWithHeight(clientCtx client.Context, addr sdk.AccAdd
 		return nil, 0, err
 	}
 
-	queryClient := authtypes.NewQueryClient(cc.GRPC)
+	queryClient := authtypes.NewQueryClient(cc.grpcConn())
 	res, err := queryClient.Account(context.Background(), &authtypes.QueryAccountRequest{Address: address}, grpc.Header(&header))
 	if err != nil {
 		return nil, 0, err
@@ -50,7 +50,12 @@ func (cc *Client) GetAccountWithHeight(clientCtx client.Context, addr sdk.AccAdd
 	var acc sdk.AccountI
 
 	if err := cc.Codec.InterfaceRegistry.UnpackAny(res.Account, &acc); err != nil {
-		return nil, 0, err
+		// accounts of app chain specific types are decoded through the types of the node
+		dynAcc, dynErr := cc.dynamicTypes().UnpackAccount(context.Background(), res.Account)
+		if dynErr != nil {
+			return nil, 0, fmt.Errorf("%s: %s", err, dynErr)
+		}
+		return dynAcc, int64(nBlockHeight), nil
 	}
 
 	return acc, int64(nBlockHeight), nil
@@ -0,0 +1,283 @@
+package compass
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	comettypes "github.com/cometbft/cometbft/types"
+)
+
+const (
+	// number of results requested per page, which is the maximum allowed by cometbft
+	DefaultSearchPerPage = 100
+	// minimum interval between page requests
+	DefaultSearchInterval = 100 * time.Millisecond
+
+	// number of times a rate limited request is retried, doubling the backoff each time
+	maxRateLimitRetries = 5
+)
+
+// A transaction included in a block
+type BlockTx struct {
+	// hex encoded hash of the transaction
+	Hash string
+	Raw  comettypes.Tx
+	// the decoded transaction, which is nil if it could not be decoded (ie: it includes messages
+	// which are not registered against the codec), in which case `DecodeErr` is set
+	Tx        *DecodedTx
+	DecodeErr error
+}
+
+// A block and its decoded transactions
+type Block struct {
+	*comettypes.Block
+	BlockID comettypes.BlockID
+	Txs     []BlockTx
+}
+
+// A transaction returned by a search, and the result of its execution
+type SearchedTx struct {
+	BlockTx
+	Height int64
+	Index  uint32
+	Result abci.ExecTxResult
+}
+
+// Returns the block at the given height
+func (c *Client) GetBlock(ctx context.Context, height int64) (*Block, error) {
+	return c.getBlock(ctx, &height)
+}
+
+// Returns the latest block
+func (c *Client) GetLatestBlock(ctx context.Context) (*Block, error) {
+	return c.getBlock(ctx, nil)
+}
+
+// Returns the results of executing the block at the given height, including the result and events
+// of each transaction and the events emitted while finalizing the block
+func (c *Client) GetBlockResults(ctx context.Context, height int64) (*coretypes.ResultBlockResults, error) {
+	res, err := c.rpcClient().BlockResults(ctx, &height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query block results %s", err)
+	}
+	return res, nil
+}
+
+// Returns an iterator over the transactions matching the query (ie: `message.sender='cosmos1...'`),
+// which requires the node to index transactions. Pages are fetched as the iterator advances
+func (c *Client) SearchTxs(query string, opts ...SearchOption) *Iterator[*SearchedTx] {
+	return NewIterator(func(ctx context.Context, page, perPage int, orderBy string) ([]*SearchedTx, int, error) {
+		res, err := c.rpcClient().TxSearch(ctx, query, false, &page, &perPage, orderBy)
+		if err != nil {
+			return nil, 0, err
+		}
+		txs := make([]*SearchedTx, 0, len(res.Txs))
+		for _, tx := range res.Txs {
+			txs = append(txs, &SearchedTx{
+				BlockTx: c.blockTx(tx.Tx),
+				Height:  tx.Height,
+				Index:   tx.Index,
+				Result:  tx.TxResult,
+			})
+		}
+		return txs, res.TotalCount, nil
+	}, opts...)
+}
+
+// Returns an iterator over the blocks matching the query (ie: `block.height > 100`), which requires
+// the node to index blocks. Pages are fetched as the iterator advances
+func (c *Client) SearchBlocks(query string, opts ...SearchOption) *Iterator[*Block] {
+	return NewIterator(func(ctx context.Context, page, perPage int, orderBy string) ([]*Block, int, error) {
+		res, err := c.rpcClient().BlockSearch(ctx, query, &page, &perPage, orderBy)
+		if err != nil {
+			return nil, 0, err
+		}
+		blocks := make([]*Block, 0, len(res.Blocks))
+		for _, block := range res.Blocks {
+			blocks = append(blocks, c.newBlock(block))
+		}
+		return blocks, res.TotalCount, nil
+	}, opts...)
+}
+
+func (c *Client) getBlock(ctx context.Context, height *int64) (*Block, error) {
+	res, err := c.rpcClient().Block(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query block %s", err)
+	}
+	return c.newBlock(res), nil
+}
+
+func (c *Client) newBlock(res *coretypes.ResultBlock) *Block {
+	block := &Block{Block: res.Block, BlockID: res.BlockID}
+	for _, tx := range res.Block.Txs {
+		block.Txs = append(block.Txs, c.blockTx(tx))
+	}
+	return block
+}
+
+func (c *Client) blockTx(tx comettypes.Tx) BlockTx {
+	decoded, err := c.DecodeTx(tx)
+	return BlockTx{
+		Hash:      fmt.Sprintf("%X", tx.Hash()),
+		Raw:       tx,
+		Tx:        decoded,
+		DecodeErr: err,
+	}
+}
+
+// Fetches a page of results, returning the results and the total number of results
+type PageFetcher[T any] func(ctx context.Context, page, perPage int, orderBy string) ([]T, int, error)
+
+// Configures the pagination of searches
+type SearchOption func(*searchOptions)
+
+type searchOptions struct {
+	perPage  int
+	orderBy  string
+	interval time.Duration
+}
+
+// Sets the number of results requested per page
+func WithPerPage(perPage int) SearchOption {
+	return func(opts *searchOptions) {
+		opts.perPage = perPage
+	}
+}
+
+// Sets the order of results, either `asc` or `desc`, defaulting to `asc`
+func WithOrderBy(orderBy string) SearchOption {
+	return func(opts *searchOptions) {
+		opts.orderBy = orderBy
+	}
+}
+
+// Sets the minimum interval between page requests, used to stay under the rate limit of public nodes
+func WithRequestInterval(interval time.Duration) SearchOption {
+	return func(opts *searchOptions) {
+		opts.interval = interval
+	}
+}
+
+// Walks the pages of a search transparently, fetching the next page once the results of the current
+// page are exhausted. Requests are spaced by the configured interval, and rate limited requests are
+// retried with exponential backoff.
+//
+//	it := client.SearchTxs("message.sender='cosmos1...'")
+//	for it.Next(ctx) {
+//		tx := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type Iterator[T any] struct {
+	fetch PageFetcher[T]
+	opts  searchOptions
+
+	page        int
+	total       int
+	fetched     int
+	done        bool
+	results     []T
+	current     T
+	err         error
+	lastRequest time.Time
+}
+
+// Returns an iterator over the pages returned by the fetcher
+func NewIterator[T any](fetch PageFetcher[T], opts ...SearchOption) *Iterator[T] {
+	options := searchOptions{
+		perPage:  DefaultSearchPerPage,
+		orderBy:  "asc",
+		interval: DefaultSearchInterval,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return &Iterator[T]{fetch: fetch, opts: options}
+}
+
+// Advances the iterator, returning false once all results have been returned or an error occurred
+func (it *Iterator[T]) Next(ctx context.Context) bool {
+	for len(it.results) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+		if err := it.nextPage(ctx); err != nil {
+			it.err = err
+			return false
+		}
+	}
+	it.current, it.results = it.results[0], it.results[1:]
+	return true
+}
+
+// Returns the result the iterator is positioned at
+func (it *Iterator[T]) Value() T {
+	return it.current
+}
+
+// Returns the error which stopped the iteration, if any
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Returns the total number of results reported by the node, which is 0 until the first page is fetched
+func (it *Iterator[T]) Total() int {
+	return it.total
+}
+
+// fetches the next page of results, waiting for the request interval to elapse
+func (it *Iterator[T]) nextPage(ctx context.Context) error {
+	backoff := it.opts.interval
+	for retries := 0; ; retries++ {
+		if wait := it.opts.interval - time.Since(it.lastRequest); wait > 0 {
+			if err := sleepContext(ctx, wait); err != nil {
+				return err
+			}
+		}
+		it.lastRequest = time.Now()
+		results, total, err := it.fetch(ctx, it.page+1, it.opts.perPage, it.opts.orderBy)
+		if err != nil {
+			if !isRateLimited(err) || retries == maxRateLimitRetries {
+				return fmt.Errorf("failed to fetch page %d %w", it.page+1, err)
+			}
+			if backoff < time.Second {
+				backoff = time.Second
+			}
+			if err := sleepContext(ctx, backoff); err != nil {
+				return err
+			}
+			backoff *= 2
+			continue
+		}
+		it.page++
+		it.total = total
+		it.fetched += len(results)
+		it.results = results
+		it.done = len(results) == 0 || it.fetched >= total
+		return nil
+	}
+}
+
+// returns true if the request was rejected by the rate limit of the node
+func isRateLimited(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "429") || strings.Contains(msg, "Too Many Requests")
+}
+
+// sleeps for the duration, returning early with the context error if the context is cancelled
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,67 @@
+package compass_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/teamscanworks/compass"
+)
+
+func TestIterator(t *testing.T) {
+	results := []int{1, 2, 3, 4, 5}
+	var pages []int
+	rateLimited := false
+	it := compass.NewIterator(func(ctx context.Context, page, perPage int, orderBy string) ([]int, int, error) {
+		require.Equal(t, "desc", orderBy)
+		if page == 2 && !rateLimited {
+			rateLimited = true
+			return nil, 0, fmt.Errorf("error in json rpc client, with http response metadata: (Status: 429 Too Many Requests, Protocol HTTP/1.1)")
+		}
+		pages = append(pages, page)
+		start := (page - 1) * perPage
+		end := start + perPage
+		if end > len(results) {
+			end = len(results)
+		}
+		return results[start:end], len(results), nil
+	}, compass.WithPerPage(2), compass.WithOrderBy("desc"), compass.WithRequestInterval(time.Millisecond))
+
+	ctx := context.Background()
+	var got []int
+	for it.Next(ctx) {
+		got = append(got, it.Value())
+	}
+	require.NoError(t, it.Err())
+	require.Equal(t, results, got)
+	require.Equal(t, []int{1, 2, 3}, pages)
+	require.Equal(t, 5, it.Total())
+	require.False(t, it.Next(ctx))
+}
+
+func TestIteratorError(t *testing.T) {
+	calls := 0
+	it := compass.NewIterator(func(ctx context.Context, page, perPage int, orderBy string) ([]string, int, error) {
+		calls++
+		return nil, 0, fmt.Errorf("connection refused")
+	})
+	require.False(t, it.Next(context.Background()))
+	require.ErrorContains(t, it.Err(), "connection refused")
+	require.Equal(t, 1, calls)
+
+	empty := compass.NewIterator(func(ctx context.Context, page, perPage int, orderBy string) ([]string, int, error) {
+		return nil, 0, nil
+	})
+	require.False(t, empty.Next(context.Background()))
+	require.NoError(t, empty.Err())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	limited := compass.NewIterator(func(ctx context.Context, page, perPage int, orderBy string) ([]string, int, error) {
+		return nil, 0, fmt.Errorf("429 Too Many Requests")
+	})
+	require.False(t, limited.Next(ctx))
+	require.ErrorIs(t, limited.Err(), context.Canceled)
+}
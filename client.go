@@ -2,9 +2,14 @@ package compass
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
+	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/cosmos/cosmos-sdk/client"
@@ -16,22 +21,48 @@ import (
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
 	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
 	"github.com/spf13/pflag"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
 )
 
+// Returned by methods of a client which has been closed
+var ErrClosed = errors.New("client is closed")
+
 // Client provides a lightweight RPC/gRPC client for interacting with the cosmos blockchain, and is a fork of https://github.com/strangelove-ventures/lens
 type Client struct {
-	log     *zap.Logger
-	cfg     *ClientConfig
+	log *zap.Logger
+	cfg *ClientConfig
+	// transports of the client, which are replaced by `Reconnect` and must not be accessed concurrently with it
 	RPC     *rpchttp.HTTP
 	GRPC    *grpc.ClientConn
 	Keyring keyring.Keyring
 
 	Codec Codec
+	// types resolved through the node, used to decode types which are not registered against `Codec`.
+	// Replaced by `Reconnect` like the transports
+	DynamicTypes *DynamicTypes
+	// journal of sent transactions, which is nil unless enabled by `ClientConfig.Journal`
+	Journal *Journal
+
+	initLock    sync.Mutex
+	initialized bool
+	closeFn     sync.Once
+	// set once `Close` is called, rejecting new transactions and subscriptions
+	closed atomic.Bool
+	// set once transports are closed, failing any request still made through them
+	transportsClosed atomic.Bool
+	rpcTransport     *closableTransport
+	// rpc endpoint currently in use, which differs from `RPCAddr` after failing over
+	rpcAddr string
+	// guards the transports, dynamic types and rpc endpoint. Requests hold a read lock while in flight,
+	// so that `Reconnect` doesn't close the transports under them
+	transportLock sync.RWMutex
 
-	initFn  sync.Once
-	closeFn sync.Once
+	// websocket multiplexing event subscriptions, started by the first subscription
+	events     *eventStream
+	eventsLock sync.Mutex
 
 	cctx    client.Context
 	factory tx.Factory
@@ -43,97 +74,195 @@ type Client struct {
 	txLock sync.Mutex
 
 	seqNum uint64
+	// hashes of transactions keyed by their idempotency key, used when the journal is disabled
+	idempotentTxs map[string]string
+
+	// provider set through `SetGasPriceProvider`, overriding the configured source
+	gasPriceProvider GasPriceProvider
+	// provider of the percentile source, kept across transactions as it caches its estimate
+	percentileGasPrices *PercentileGasPrices
+	percentileOnce      sync.Once
 }
 
 // Returns a new compass client used to interact with the cosmos blockchain
 func NewClient(log *zap.Logger, cfg *ClientConfig, keyringOptions []keyring.Option) (*Client, error) {
 	logger := log.Named("compass")
+	if err := ValidateCodecBundles(cfg.ExtraCodecs); err != nil {
+		return nil, err
+	}
 	rpc := &Client{
 		log:   logger,
 		cfg:   cfg,
-		Codec: MakeCodec(cfg.Modules, []string{}),
+		Codec: MakeCodec(cfg.Modules, cfg.ExtraCodecs),
 	}
 	return rpc, rpc.Initialize(keyringOptions)
 }
 
-// Closes internal clients used by compass
-func (c *Client) Close() error {
-	var closeErr error = fmt.Errorf("client already close")
+// Closes the client, after which methods return `ErrClosed`. Subscriptions are closed, and pending
+// transactions are given until the context is done to complete before the gRPC and RPC transports are
+// closed. Calling `Close` more than once has no effect
+func (c *Client) Close(ctx context.Context) error {
+	var closeErr error
 	c.closeFn.Do(func() {
-		if closeErr = c.GRPC.Close(); closeErr != nil {
-			return
+		c.closed.Store(true)
+		var errs []error
+		if err := c.drainTxs(ctx); err != nil {
+			errs = append(errs, err)
 		}
-		closeErr = nil
-
+		c.stopEvents()
+		if c.Journal != nil {
+			if err := c.Journal.Close(); err != nil {
+				errs = append(errs, fmt.Errorf("failed to close journal %s", err))
+			}
+		}
+		c.transportsClosed.Store(true)
+		c.transportLock.Lock()
+		if c.GRPC != nil {
+			if err := c.GRPC.Close(); err != nil {
+				errs = append(errs, fmt.Errorf("failed to close grpc connection %s", err))
+			}
+		}
+		if c.rpcTransport != nil {
+			c.rpcTransport.CloseIdleConnections()
+		}
+		c.transportLock.Unlock()
+		closeErr = errors.Join(errs...)
+		c.log.Info("closed client")
 	})
 	return closeErr
 }
 
-// Initializes the compass client, and should be called immediately after instantiation
+// waits for the transaction being sent, if any, to complete
+func (c *Client) drainTxs(ctx context.Context) error {
+	drained := make(chan struct{})
+	go func() {
+		c.txLock.Lock()
+		defer c.txLock.Unlock()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("failed to drain pending transactions %s", ctx.Err())
+	}
+}
+
+// returns `ErrClosed` if the client has been closed
+func (c *Client) checkClosed() error {
+	if c.closed.Load() {
+		return ErrClosed
+	}
+	return nil
+}
+
+// Initializes the compass client, and should be called immediately after instantiation. The chain ID
+// reported by the node must match the configured chain ID, although initialization proceeds with a warning
+// if the node can't be reached. If initialization fails anything opened is released, such that it may be
+// retried, while calling it again once successful has no effect
 func (c *Client) Initialize(keyringOptions []keyring.Option) error {
-	var initErr error = nil
-	c.initFn.Do(func() {
-		if c.log == nil {
-			initErr = fmt.Errorf("invalid client object: no logger")
-			return
-		}
-		if c.cfg == nil {
-			initErr = fmt.Errorf("invalid client object: no config")
-			return
+	if c.log == nil {
+		return fmt.Errorf("invalid client object: no logger")
+	}
+	if c.cfg == nil {
+		return fmt.Errorf("invalid client object: no config")
+	}
+	c.initLock.Lock()
+	defer c.initLock.Unlock()
+	if c.initialized {
+		return nil
+	}
+	if err := c.initialize(keyringOptions); err != nil {
+		c.transportLock.Lock()
+		c.releaseTransports()
+		c.transportLock.Unlock()
+		c.Keyring = nil
+		if c.Journal != nil {
+			_ = c.Journal.Close()
+			c.Journal = nil
 		}
+		return err
+	}
+	c.initialized = true
+	c.log.Info("initialized client")
+	return nil
+}
 
-		keyInfo, err := keyring.New(c.cfg.ChainID, c.cfg.KeyringBackend, c.cfg.KeyDirectory, os.Stdin, c.Codec.Marshaler, keyringOptions...)
-		if err != nil {
-			initErr = fmt.Errorf("failed to initialize keyring %s", err)
-			return
+func (c *Client) initialize(keyringOptions []keyring.Option) error {
+	if c.cfg.KeyringBackend == keyring.BackendMemory {
+		// ephemeral keyring which never touches disk
+		c.Keyring = keyring.NewInMemory(c.Codec.Marshaler, keyringOptions...)
+	} else {
+		var userInput io.Reader = os.Stdin
+		if c.cfg.Passphrase != nil && c.cfg.KeyringBackend != keyring.BackendTest {
+			// the keyring prompts the terminal instead of reading the passphrase from the provider
+			if stdinIsTerminal() {
+				return fmt.Errorf("failed to initialize keyring passphrase provider is unsupported when stdin is a terminal")
+			}
+			userInput = NewPassphraseReader(c.cfg.Passphrase)
 		}
-		c.Keyring = keyInfo
 
-		rpc, err := NewRPCClient(c.cfg.RPCAddr, time.Second*30)
+		keyInfo, err := keyring.New(c.cfg.ChainID, c.cfg.KeyringBackend, c.cfg.KeyDirectory, userInput, c.Codec.Marshaler, keyringOptions...)
 		if err != nil {
-			initErr = fmt.Errorf("failed to construct rpc client %v", err)
+			return fmt.Errorf("failed to initialize keyring %s", err)
 		}
-		c.RPC = rpc
+		c.Keyring = keyInfo
+	}
 
-		grpcConn, err := grpc.Dial(
-			c.cfg.GRPCAddr,      // your gRPC server address.
-			grpc.WithInsecure(), // The Cosmos SDK doesn't support any transport security mechanism
-		)
+	if path := c.cfg.journalPath(); path != "" {
+		journal, err := OpenJournal(path)
 		if err != nil {
-			initErr = fmt.Errorf("failed to dial grpc server node %s", err)
-			return
+			return err
 		}
-		c.GRPC = grpcConn
+		c.Journal = journal
+	}
 
-		signOpts, err := authtx.NewDefaultSigningOptions()
-		if err != nil {
-			initErr = fmt.Errorf("failed to get tx opts %s", err)
-			return
-		}
-		txCfg, err := authtx.NewTxConfigWithOptions(c.Codec.Marshaler, authtx.ConfigOptions{
-			SigningOptions: signOpts,
-		})
-		if err != nil {
-			initErr = fmt.Errorf("failed to initialize tx config %s", err)
-			return
-		}
-		c.cctx = c.configClientContext(client.Context{}.WithTxConfig(txCfg))
+	rpc, transport, err := c.dialRPC(c.cfg.RPCAddr)
+	if err != nil {
+		return err
+	}
+	grpcConn, err := c.dialGRPC(c.cfg.GRPCAddr)
+	if err != nil {
+		return err
+	}
+	c.transportLock.Lock()
+	c.setTransports(rpc, transport, c.cfg.RPCAddr, grpcConn)
+	c.transportLock.Unlock()
 
-		factory, err := tx.NewFactoryCLI(c.cctx, pflag.NewFlagSet("", pflag.ExitOnError))
-		if err != nil {
-			initErr = fmt.Errorf("failed to initialize tx factory %s", err)
-			return
+	ctx, cancel := context.WithTimeout(context.Background(), chainIDCheckTimeout)
+	defer cancel()
+	if err := c.verifyRPCChainID(ctx, rpc, c.cfg.RPCAddr); err != nil {
+		if errors.Is(err, ErrChainIDMismatch) {
+			return err
 		}
-		c.factory = c.configTxFactory(factory.WithTxConfig(txCfg))
+		c.log.Warn("unable to verify chain id of node", zap.String("rpc.addr", c.cfg.RPCAddr), zap.Error(err))
+	}
 
-		c.log.Info("initialized client")
+	signOpts, err := authtx.NewDefaultSigningOptions()
+	if err != nil {
+		return fmt.Errorf("failed to get tx opts %s", err)
+	}
+	txCfg, err := authtx.NewTxConfigWithOptions(c.Codec.Marshaler, authtx.ConfigOptions{
+		SigningOptions: signOpts,
 	})
+	if err != nil {
+		return fmt.Errorf("failed to initialize tx config %s", err)
+	}
+	c.cctx = c.configClientContext(client.Context{}.WithTxConfig(txCfg))
 
-	return initErr
+	factory, err := tx.NewFactoryCLI(c.cctx, pflag.NewFlagSet("", pflag.ExitOnError))
+	if err != nil {
+		return fmt.Errorf("failed to initialize tx factory %s", err)
+	}
+	c.factory = c.configTxFactory(factory.WithTxConfig(txCfg))
+	return nil
 }
 
 // Triggers keyring migration, ensuring that the factory, and client context are updated
 func (c *Client) MigrateKeyring() error {
+	if err := c.checkClosed(); err != nil {
+		return err
+	}
 	_, err := c.Keyring.MigrateAll()
 	if err != nil {
 		return err
@@ -150,17 +279,41 @@ func (c *Client) UpdateFromName(name string) {
 }
 
 // Sends and confirms the given message, returning the hex encoded transaction hash
-// if the transaction was successfully confirmed.
-func (c *Client) SendTransaction(ctx context.Context, msg sdktypes.Msg) (string, error) {
+// if the transaction was successfully confirmed. Sends given an idempotency key through
+// `WithIdempotencyKey` may be safely retried after an ambiguous failure.
+func (c *Client) SendTransaction(ctx context.Context, msg sdktypes.Msg, opts ...SendOption) (_ string, err error) {
+	options := &sendOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	if err := c.checkClosed(); err != nil {
+		return "", err
+	}
+	ctx, span := c.startSpan(ctx, "compass.SendTransaction", AttributeMsgTypes.StringSlice(msgTypeURLs([]sdktypes.Msg{msg})))
+	defer func() { endSpan(span, err) }()
 	c.txLock.Lock()
 	defer c.txLock.Unlock()
-	if err := c.prepare(); err != nil {
+	if err := c.prepare(ctx); err != nil {
 		return "", fmt.Errorf("transaction preparation failed %v", err)
 	}
-	txHash, err := c.BroadcastTx(ctx, msg)
+	if options.idempotencyKey != "" {
+		txHash, err := c.resumeIdempotentTx(ctx, options.idempotencyKey)
+		if err != nil {
+			return "", fmt.Errorf("failed to resume transaction %w", err)
+		}
+		if txHash != "" {
+			span.SetAttributes(AttributeSigner.String(c.FromAddress()), AttributeTxHash.String(txHash))
+			return txHash, nil
+		}
+	}
+	if err := c.checkPendingTx(c.seqNum); err != nil {
+		return "", err
+	}
+	txHash, err := c.broadcastTx(ctx, options.idempotencyKey, nil, msg)
 	if err != nil {
 		return "", fmt.Errorf("failed to broadcast transaction %v", err)
 	}
+	span.SetAttributes(AttributeSigner.String(c.FromAddress()), AttributeTxHash.String(txHash))
 	c.log.Info("sent transaction", zap.String("tx.hash", txHash))
 	return txHash, nil
 }
@@ -198,15 +351,15 @@ func (c *Client) GetActiveKeypair() (*sdktypes.AccAddress, error) {
 	return &kp, nil
 }
 
-// Returns the keyring record located at the given index, returning an error
-// if there are less than `idx` keys in the keyring
+// Returns the keyring record located at the given index, returning a `KeyNotFoundError`
+// if the index is out of range
 func (c *Client) KeyringRecordAt(idx int) (*keyring.Record, error) {
 	keys, err := c.Keyring.List()
 	if err != nil {
 		return nil, err
 	}
-	if len(keys) < idx-1 {
-		return nil, fmt.Errorf("key length %v less than index %v", len(keys), idx)
+	if idx < 0 || idx >= len(keys) {
+		return nil, &KeyNotFoundError{By: "index", Value: strconv.Itoa(idx)}
 	}
 	return keys[idx], nil
 }
@@ -214,7 +367,9 @@ func (c *Client) KeyringRecordAt(idx int) (*keyring.Record, error) {
 // ensures that all necessary configs are set to enable transaction sending
 //
 // TODO: likely not very performant
-func (c *Client) prepare() error {
+func (c *Client) prepare(ctx context.Context) (err error) {
+	_, span := c.startSpan(ctx, "compass.prepare")
+	defer func() { endSpan(span, err) }()
 	kp, err := c.GetActiveKeypair()
 	if err != nil {
 		return err
@@ -252,8 +407,6 @@ func (c *Client) configClientContext(cctx client.Context) client.Context {
 		WithAccountRetriever(c).
 		WithChainID(c.cfg.ChainID).
 		WithKeyring(c.Keyring).
-		WithGRPCClient(c.GRPC).
-		WithClient(c.RPC).
 		WithSignModeStr(signing.SignMode_SIGN_MODE_DIRECT.String()).
 		WithCodec(c.Codec.Marshaler).
 		WithInterfaceRegistry(c.Codec.InterfaceRegistry).
@@ -262,3 +415,74 @@ func (c *Client) configClientContext(cctx client.Context) client.Context {
 		// transactions with cosmos-sdk as it will expect the user to provide input
 		WithSkipConfirmation(true)
 }
+
+// fails gRPC requests made once the transports are closed, and records the latency and spans of requests
+func (c *Client) unaryInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if c.transportsClosed.Load() {
+		return ErrClosed
+	}
+	c.transportLock.RLock()
+	defer c.transportLock.RUnlock()
+	// calls made through a connection closed by `Reconnect` are made through its replacement instead
+	if cc.GetState() == connectivity.Shutdown && c.GRPC != nil && c.GRPC != cc {
+		cc = c.GRPC
+	}
+	ctx, span := c.tracer().Start(ctx, method, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		AttributeChainID.String(c.cfg.ChainID),
+		AttributeMethod.String(method),
+	))
+	start := time.Now()
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	c.cfg.Metrics.observeCall("grpc", method, time.Since(start), err)
+	endSpan(span, err)
+	return err
+}
+
+// fails gRPC streams opened once the transports are closed. Unlike unary calls streams aren't waited on
+// by `Reconnect`, as they may be left open indefinitely
+func (c *Client) streamInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	if c.transportsClosed.Load() {
+		return nil, ErrClosed
+	}
+	return streamer(ctx, desc, cc, method, opts...)
+}
+
+// An http transport failing RPC requests made once the transports are closed, and recording the
+// latency and spans of requests
+type closableTransport struct {
+	http.RoundTripper
+	closed *atomic.Bool
+	// held for reading while requests are in flight
+	inFlight *sync.RWMutex
+	metrics  *Metrics
+	tracer   trace.Tracer
+	chainID  string
+}
+
+func (t *closableTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.closed.Load() {
+		return nil, ErrClosed
+	}
+	t.inFlight.RLock()
+	defer t.inFlight.RUnlock()
+	method := rpcMethod(req)
+	_, span := t.tracer.Start(req.Context(), method, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		AttributeChainID.String(t.chainID),
+		AttributeMethod.String(method),
+	))
+	start := time.Now()
+	res, err := t.RoundTripper.RoundTrip(req)
+	callErr := err
+	if err == nil && res.StatusCode >= http.StatusBadRequest {
+		callErr = fmt.Errorf("status %d", res.StatusCode)
+	}
+	t.metrics.observeCall("rpc", method, time.Since(start), callErr)
+	endSpan(span, callErr)
+	return res, err
+}
+
+func (t *closableTransport) CloseIdleConnections() {
+	if closer, ok := t.RoundTripper.(interface{ CloseIdleConnections() }); ok {
+		closer.CloseIdleConnections()
+	}
+}
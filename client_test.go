@@ -3,8 +3,11 @@ package compass_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	"github.com/stretchr/testify/require"
 	"github.com/teamscanworks/compass"
 	"go.uber.org/zap"
@@ -21,7 +24,28 @@ func TestClient(t *testing.T) {
 	require.NotNil(t, client.RPC)
 	require.NotNil(t, client.Keyring)
 	require.NotNil(t, client.Codec)
-	abcInfo, err := client.RPC.ABCIInfo(context.Background())
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	require.NoError(t, client.WaitForHeight(ctx, 1))
+	abcInfo, err := client.RPC.ABCIInfo(ctx)
 	require.NoError(t, err)
 	require.GreaterOrEqual(t, abcInfo.Response.LastBlockHeight, int64(1))
 }
+
+func TestClose(t *testing.T) {
+	client, _ := newMemoryClient(t)
+	ctx := context.Background()
+	require.NoError(t, client.Close(ctx))
+	require.NoError(t, client.Close(ctx))
+
+	_, err := client.SendTransaction(ctx, &banktypes.MsgSend{Amount: sdk.NewCoins(sdk.NewInt64Coin("stake", 1))})
+	require.ErrorIs(t, err, compass.ErrClosed)
+	_, err = client.BroadcastTx(ctx, &banktypes.MsgSend{Amount: sdk.NewCoins(sdk.NewInt64Coin("stake", 1))})
+	require.ErrorIs(t, err, compass.ErrClosed)
+	_, err = client.SubscribeNewBlocks(ctx)
+	require.ErrorIs(t, err, compass.ErrClosed)
+	_, err = client.RPC.Status(ctx)
+	require.ErrorIs(t, err, compass.ErrClosed)
+	_, err = banktypes.NewQueryClient(client.GRPC).Params(ctx, &banktypes.QueryParamsRequest{})
+	require.ErrorIs(t, err, compass.ErrClosed)
+}
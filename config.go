@@ -1,9 +1,21 @@
 package compass
 
 import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
 	"path"
+	"strings"
 	"time"
 
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slices"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
 	paramsclient "github.com/cosmos/cosmos-sdk/x/params/client"
 
 	"github.com/cosmos/cosmos-sdk/types/module"
@@ -18,6 +30,9 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/cosmos/cosmos-sdk/x/slashing"
 	"github.com/cosmos/cosmos-sdk/x/staking"
+
+	"github.com/teamscanworks/compass/types/feegrant"
+	"github.com/teamscanworks/compass/types/ibc"
 )
 
 var (
@@ -26,13 +41,14 @@ var (
 		auth.AppModuleBasic{},
 		authz.AppModuleBasic{},
 		bank.AppModuleBasic{},
-		// TODO: add osmosis governance proposal types here
 		// TODO: add other proposal types here
 		gov.NewAppModuleBasic([]client.ProposalHandler{
 			paramsclient.ProposalHandler,
 		}),
 		crisis.AppModuleBasic{},
 		distribution.AppModuleBasic{},
+		feegrant.AppModuleBasic{},
+		ibc.AppModuleBasic{},
 		mint.AppModuleBasic{},
 		params.AppModuleBasic{},
 		slashing.AppModuleBasic{},
@@ -40,6 +56,27 @@ var (
 	}
 )
 
+var (
+	// sign modes which may be used as the `SignModeStr`
+	signModes = []string{
+		flags.SignModeDirect,
+		flags.SignModeLegacyAminoJSON,
+		flags.SignModeDirectAux,
+		flags.SignModeTextual,
+	}
+
+	// Keyring backends which may be used as the `KeyringBackend`, the `memory` backend
+	// is ephemeral and never persists keys to disk
+	KeyringBackends = []string{
+		keyring.BackendOS,
+		keyring.BackendFile,
+		keyring.BackendKWallet,
+		keyring.BackendPass,
+		keyring.BackendTest,
+		keyring.BackendMemory,
+	}
+)
+
 // Allows configuration of the compass client
 type ClientConfig struct {
 	// the name of that is used as the `FromName` for transaction signing
@@ -61,17 +98,145 @@ type ClientConfig struct {
 	ExtraCodecs    []string                `json:"extra-codecs" yaml:"extra-codecs"`
 	Modules        []module.AppModuleBasic `json:"-" yaml:"-"`
 	Slip44         int                     `json:"slip44" yaml:"slip44"`
+	// source of the gas prices paid by transactions, one of `static` (`GasPrices`), `node` (the node's
+	// `minimum-gas-prices`), `feemarket` (the x/feemarket base fee) or `percentile` (recent blocks), defaulting to static
+	GasPriceSource string `json:"gas-price-source" yaml:"gas-price-source"`
+	// percentile of the gas prices paid in recent blocks used by the percentile source, defaulting to 50
+	GasPricePercentile float64 `json:"gas-price-percentile" yaml:"gas-price-percentile"`
+	// bounds of the gas prices of any source, by denom
+	MinGasPrices string `json:"min-gas-prices" yaml:"min-gas-prices"`
+	MaxGasPrices string `json:"max-gas-prices" yaml:"max-gas-prices"`
+	// alternate endpoints which may be used in place of `RPCAddr` and `GRPCAddr`
+	RPCAddrs  []string `json:"rpc-addrs" yaml:"rpc-addrs"`
+	GRPCAddrs []string `json:"grpc-addrs" yaml:"grpc-addrs"`
+	// optional provider used to unlock the `file` and `os` keyring backends, when unset
+	// the passphrase is read from stdin
+	Passphrase PassphraseProvider `json:"-" yaml:"-"`
+	// records sent transactions in an on-disk journal, stored at `JournalPath` or in `KeyDirectory`
+	Journal     bool   `json:"journal" yaml:"journal"`
+	JournalPath string `json:"journal-path" yaml:"journal-path"`
+	// optional prometheus collectors, when unset no metrics are recorded
+	Metrics *Metrics `json:"-" yaml:"-"`
+	// optional provider of the tracer recording spans, when unset the global provider is used which
+	// records nothing unless one is registered
+	TracerProvider trace.TracerProvider `json:"-" yaml:"-"`
 }
 
-// Validates the client configuration
+// Validates the client configuration, returning all validation errors joined together
 func (ccc *ClientConfig) Validate() error {
+	var errs []error
+	if ccc.Key == "" {
+		errs = append(errs, fmt.Errorf("key must not be empty"))
+	}
+	if ccc.ChainID == "" {
+		errs = append(errs, fmt.Errorf("chain-id must not be empty"))
+	}
+	if err := validateRPCAddr(ccc.RPCAddr); err != nil {
+		errs = append(errs, fmt.Errorf("invalid rpc-addr %q %v", ccc.RPCAddr, err))
+	}
+	if err := validateGRPCAddr(ccc.GRPCAddr); err != nil {
+		errs = append(errs, fmt.Errorf("invalid grpc-addr %q %v", ccc.GRPCAddr, err))
+	}
+	for _, addr := range ccc.RPCAddrs {
+		if err := validateRPCAddr(addr); err != nil {
+			errs = append(errs, fmt.Errorf("invalid rpc-addrs entry %q %v", addr, err))
+		}
+	}
+	for _, addr := range ccc.GRPCAddrs {
+		if err := validateGRPCAddr(addr); err != nil {
+			errs = append(errs, fmt.Errorf("invalid grpc-addrs entry %q %v", addr, err))
+		}
+	}
+	if ccc.AccountPrefix == "" {
+		errs = append(errs, fmt.Errorf("account-prefix must not be empty"))
+	}
+	if !slices.Contains(KeyringBackends, ccc.KeyringBackend) {
+		errs = append(errs, fmt.Errorf("unsupported keyring backend %q", ccc.KeyringBackend))
+	}
+	if ccc.KeyDirectory == "" && ccc.KeyringBackend != keyring.BackendMemory {
+		errs = append(errs, fmt.Errorf("key-directory must not be empty"))
+	}
+	if ccc.Journal && ccc.JournalPath == "" && ccc.KeyDirectory == "" {
+		errs = append(errs, fmt.Errorf("journal requires a journal-path or key-directory"))
+	}
+	if ccc.GasAdjustment <= 0 {
+		errs = append(errs, fmt.Errorf("gas-adjustment must be positive"))
+	}
+	if ccc.GasPrices != "" {
+		if _, err := sdk.ParseDecCoins(ccc.GasPrices); err != nil {
+			errs = append(errs, fmt.Errorf("invalid gas-prices %q %v", ccc.GasPrices, err))
+		}
+	}
+	if ccc.GasPriceSource != "" && !slices.Contains(gasPriceSources, ccc.GasPriceSource) {
+		errs = append(errs, fmt.Errorf("unsupported gas-price-source %q", ccc.GasPriceSource))
+	}
+	if ccc.GasPricePercentile < 0 || ccc.GasPricePercentile > 100 {
+		errs = append(errs, fmt.Errorf("gas-price-percentile must be between 0 and 100"))
+	}
+	for name, prices := range map[string]string{"min-gas-prices": ccc.MinGasPrices, "max-gas-prices": ccc.MaxGasPrices} {
+		if _, err := sdk.ParseDecCoins(prices); err != nil {
+			errs = append(errs, fmt.Errorf("invalid %s %q %v", name, prices, err))
+		}
+	}
 	if _, err := time.ParseDuration(ccc.Timeout); err != nil {
-		return err
+		errs = append(errs, fmt.Errorf("invalid timeout %q %v", ccc.Timeout, err))
 	}
 	if ccc.BlockTimeout != "" {
 		if _, err := time.ParseDuration(ccc.BlockTimeout); err != nil {
+			errs = append(errs, fmt.Errorf("invalid block-timeout %q %v", ccc.BlockTimeout, err))
+		}
+	}
+	if ccc.OutputFormat != "" && ccc.OutputFormat != "json" && ccc.OutputFormat != "text" {
+		errs = append(errs, fmt.Errorf("unsupported output-format %q", ccc.OutputFormat))
+	}
+	if ccc.SignModeStr != "" && !slices.Contains(signModes, ccc.SignModeStr) {
+		errs = append(errs, fmt.Errorf("unsupported sign-mode %q", ccc.SignModeStr))
+	}
+	if err := ValidateCodecBundles(ccc.ExtraCodecs); err != nil {
+		errs = append(errs, fmt.Errorf("invalid extra-codecs %v", err))
+	}
+	if ccc.Slip44 < 0 {
+		errs = append(errs, fmt.Errorf("slip44 must not be negative"))
+	}
+	return errors.Join(errs...)
+}
+
+// validates the address of a cometbft rpc endpoint, ie: tcp://127.0.0.1:26657
+func validateRPCAddr(addr string) error {
+	u, err := url.Parse(addr)
+	if err != nil {
+		return err
+	}
+	switch u.Scheme {
+	case "http", "https", "tcp", "ws", "wss":
+		if u.Host == "" {
+			return fmt.Errorf("missing host")
+		}
+	case "unix":
+		if u.Path == "" {
+			return fmt.Errorf("missing socket path")
+		}
+	default:
+		return fmt.Errorf("unsupported scheme %q", u.Scheme)
+	}
+	return nil
+}
+
+// validates the address of a gRPC endpoint, either in host:port form or as a url
+func validateGRPCAddr(addr string) error {
+	if strings.Contains(addr, "://") {
+		u, err := url.Parse(addr)
+		if err != nil {
 			return err
 		}
+		addr = u.Host
+	}
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return err
+	}
+	if host == "" || port == "" {
+		return fmt.Errorf("missing host or port")
 	}
 	return nil
 }
@@ -86,50 +251,46 @@ func (ccc *ClientConfig) SetKeysDir(home string) {
 	ccc.KeyDirectory = ccc.FormatKeysDir(home)
 }
 
-// Returns a configuration suitable for the cosmoshub chain
+// Returns a configuration suitable for the cosmoshub chain, using the embedded chain-registry snapshot
 func GetCosmosHubConfig(keyHome string, debug bool) *ClientConfig {
-	cfg := &ClientConfig{
-		Key:            "default",
-		ChainID:        "cosmoshub-4",
-		RPCAddr:        "https://cosmoshub-4.technofractal.com:443",
-		GRPCAddr:       "https://gprc.cosmoshub-4.technofractal.com:443",
-		AccountPrefix:  "cosmos",
-		KeyringBackend: "test",
-		GasAdjustment:  1.2,
-		GasPrices:      "0.01uatom",
-		MinGasAmount:   0,
-		KeyDirectory:   keyHome,
-		Debug:          debug,
-		Timeout:        "20s",
-		OutputFormat:   "json",
-		SignModeStr:    "direct",
-		Modules:        ModuleBasics,
-	}
-	cfg.SetKeysDir(keyHome)
-	return cfg
+	return chainRegistryPreset("cosmoshub", keyHome, debug)
 }
 
-// Returns a configuration suitable for the osmosis blockchain
+// Returns a configuration suitable for the osmosis blockchain, using the embedded chain-registry snapshot.
+// The osmosis and wasm codec bundles are enabled, allowing osmosis transactions and proposals to be decoded
 func GetOsmosisConfig(keyHome string, debug bool) *ClientConfig {
-	cfg := &ClientConfig{
-		Key:            "default",
-		ChainID:        "osmosis-1",
-		RPCAddr:        "https://osmosis-1.technofractal.com:443",
-		GRPCAddr:       "https://gprc.osmosis-1.technofractal.com:443",
-		AccountPrefix:  "osmo",
-		KeyringBackend: "test",
-		GasAdjustment:  1.2,
-		GasPrices:      "0.01uosmo",
-		MinGasAmount:   0,
-		KeyDirectory:   keyHome,
-		Debug:          debug,
-		Timeout:        "20s",
-		OutputFormat:   "json",
-		SignModeStr:    "direct",
-		Modules:        ModuleBasics,
+	cfg := chainRegistryPreset("osmosis", keyHome, debug)
+	cfg.ExtraCodecs = []string{"osmosis", "wasm"}
+	return cfg
+}
+
+// configurations of the chains with presets, parsed once from the embedded chain-registry snapshot. The
+// snapshot is validated by the tests, so every preset is expected to be present
+var chainRegistryPresets = loadChainRegistryPresets("cosmoshub", "osmosis")
+
+func loadChainRegistryPresets(chainNames ...string) map[string]ClientConfig {
+	presets := make(map[string]ClientConfig, len(chainNames))
+	for _, chainName := range chainNames {
+		if cfg, err := GetChainRegistryConfig(chainName, ""); err == nil {
+			presets[chainName] = *cfg
+		}
 	}
+	return presets
+}
+
+// returns a copy of the preset configuration of a chain. Should the snapshot lack the chain, the
+// returned configuration fails validation rather than panicking
+func chainRegistryPreset(chainName, keyHome string, debug bool) *ClientConfig {
+	cfg := chainRegistryPresets[chainName]
+	if cfg.Key == "" {
+		cfg.Key = "default"
+	}
+	cfg.RPCAddrs = append([]string(nil), cfg.RPCAddrs...)
+	cfg.GRPCAddrs = append([]string(nil), cfg.GRPCAddrs...)
+	cfg.ExtraCodecs = append([]string(nil), cfg.ExtraCodecs...)
 	cfg.SetKeysDir(keyHome)
-	return cfg
+	cfg.Debug = debug
+	return &cfg
 }
 
 // Returns a configuration suitable for usage in simd environments
@@ -0,0 +1,177 @@
+package compass
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// prefix of environment variables which override configuration values
+const EnvPrefix = "COMPASS_"
+
+// Preset configurations keyed by chain id, used to fill in default values for fields
+// omitted from configuration files
+var ChainPresets = map[string]func(keyHome string, debug bool) *ClientConfig{
+	"cosmoshub-4": GetCosmosHubConfig,
+	"osmosis-1":   GetOsmosisConfig,
+	"testing": func(string, bool) *ClientConfig {
+		return GetSimdConfig()
+	},
+}
+
+// Loads the client configuration from a YAML (.yaml, .yml) or JSON (.json) file. Values in the file
+// may be overridden through `COMPASS_*` environment variables (ie: `COMPASS_CHAIN_ID`), and any
+// fields left unset are populated from the preset matching the chain id. When no key directory is
+// configured, keys are stored relative to the directory of the configuration file.
+//
+// The resulting configuration is validated before being returned
+func LoadConfig(path string) (*ClientConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config %s", err)
+	}
+	cfg := &ClientConfig{}
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, cfg)
+	case ".json":
+		err = json.Unmarshal(data, cfg)
+	default:
+		return nil, fmt.Errorf("unsupported config file extension %q", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config %s", err)
+	}
+	if err := cfg.ApplyEnvOverrides(); err != nil {
+		return nil, err
+	}
+	if cfg.KeyDirectory == "" {
+		cfg.SetKeysDir(filepath.Dir(path))
+	}
+	cfg.FillDefaults()
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config %w", err)
+	}
+	return cfg, nil
+}
+
+// Overrides configuration values with those set in `COMPASS_*` environment variables. The variable
+// name is derived from the field's yaml tag, such that `gas-prices` is overridden by `COMPASS_GAS_PRICES`.
+// List values are comma separated.
+func (ccc *ClientConfig) ApplyEnvOverrides() error {
+	val := reflect.ValueOf(ccc).Elem()
+	for i := 0; i < val.NumField(); i++ {
+		field := val.Type().Field(i)
+		name := configFieldName(field)
+		if name == "" {
+			continue
+		}
+		envName := EnvPrefix + strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+		raw, ok := os.LookupEnv(envName)
+		if !ok {
+			continue
+		}
+		if err := setConfigField(val.Field(i), raw); err != nil {
+			return fmt.Errorf("invalid value for %s %v", envName, err)
+		}
+	}
+	return nil
+}
+
+// Populates unset fields with the values from the preset matching the chain id, falling back to
+// the simd preset for chains without a preset. Chain specific fields (ie: endpoints) are never defaulted
+// for unknown chains
+func (ccc *ClientConfig) FillDefaults() {
+	preset, ok := ChainPresets[ccc.ChainID]
+	if !ok {
+		preset = ChainPresets["testing"]
+	}
+	defaults := preset("", ccc.Debug)
+	val := reflect.ValueOf(ccc).Elem()
+	defVal := reflect.ValueOf(defaults).Elem()
+	for i := 0; i < val.NumField(); i++ {
+		field := val.Type().Field(i)
+		// booleans can not distinguish unset values from false
+		if field.Type.Kind() == reflect.Bool || (!ok && chainSpecificFields[field.Name]) {
+			continue
+		}
+		if val.Field(i).IsZero() {
+			val.Field(i).Set(defVal.Field(i))
+		}
+	}
+}
+
+// fields of ClientConfig which are only defaulted from presets matching the chain id
+var chainSpecificFields = map[string]bool{
+	"ChainID":       true,
+	"RPCAddr":       true,
+	"GRPCAddr":      true,
+	"RPCAddrs":      true,
+	"GRPCAddrs":     true,
+	"AccountPrefix": true,
+	"GasPrices":     true,
+	"KeyDirectory":  true,
+	"Slip44":        true,
+}
+
+// returns the configuration name of the field as given by its yaml tag, or an empty string
+// for fields which are not configurable
+func configFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+	if name == "-" {
+		return ""
+	}
+	return name
+}
+
+// parses the raw value into the given field
+func setConfigField(field reflect.Value, raw string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(raw)
+	case reflect.Bool:
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		field.SetBool(v)
+	case reflect.Int, reflect.Int64:
+		v, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetInt(v)
+	case reflect.Uint64:
+		v, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetUint(v)
+	case reflect.Float64:
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(v)
+	case reflect.Slice:
+		if field.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported list type %s", field.Type())
+		}
+		var values []string
+		for _, v := range strings.Split(raw, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+		field.Set(reflect.ValueOf(values))
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+	return nil
+}
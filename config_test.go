@@ -1,6 +1,8 @@
 package compass_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -13,3 +15,120 @@ func TestKeysDir(t *testing.T) {
 		require.Equal(t, cfg.KeyDirectory, "keyring-test/keys/cosmoshub-4")
 	})
 }
+
+func TestValidateKeyringBackend(t *testing.T) {
+	cfg := compass.GetSimdConfig()
+	for _, backend := range compass.KeyringBackends {
+		cfg.KeyringBackend = backend
+		require.NoError(t, cfg.Validate())
+	}
+	cfg.KeyringBackend = "bogus"
+	require.Error(t, cfg.Validate())
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	t.Run("YAML", func(t *testing.T) {
+		path := filepath.Join(dir, "config.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(`
+chain-id: cosmoshub-4
+rpc-addr: https://rpc.example.com:443
+extra-codecs: [ibc]
+`), 0o600))
+		cfg, err := compass.LoadConfig(path)
+		require.NoError(t, err)
+		require.Equal(t, "https://rpc.example.com:443", cfg.RPCAddr)
+		require.Equal(t, []string{"ibc"}, cfg.ExtraCodecs)
+		// defaults populated from the cosmoshub preset
+		preset := compass.GetCosmosHubConfig("", false)
+		require.Equal(t, preset.GRPCAddr, cfg.GRPCAddr)
+		require.Equal(t, preset.GasPrices, cfg.GasPrices)
+		require.Equal(t, "cosmos", cfg.AccountPrefix)
+		require.Equal(t, filepath.Join(dir, "keys", "cosmoshub-4"), cfg.KeyDirectory)
+		require.NotEmpty(t, cfg.Modules)
+		requireCodec(t, cfg, "/cosmos.bank.v1beta1.MsgSend", "/ibc.applications.transfer.v1.MsgTransfer")
+	})
+	t.Run("JSON", func(t *testing.T) {
+		path := filepath.Join(dir, "config.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{
+			"chain-id": "mychain-1",
+			"rpc-addr": "tcp://127.0.0.1:26657",
+			"grpc-addr": "127.0.0.1:9090",
+			"account-prefix": "my",
+			"gas-prices": "0.1umy",
+			"key-directory": "/tmp/keys"
+		}`), 0o600))
+		cfg, err := compass.LoadConfig(path)
+		require.NoError(t, err)
+		require.Equal(t, "mychain-1", cfg.ChainID)
+		require.Equal(t, "/tmp/keys", cfg.KeyDirectory)
+		require.Equal(t, "default", cfg.Key)
+		require.Equal(t, "20s", cfg.Timeout)
+		require.False(t, cfg.Debug)
+		requireCodec(t, cfg, "/cosmos.bank.v1beta1.MsgSend")
+	})
+	t.Run("EnvOverrides", func(t *testing.T) {
+		path := filepath.Join(dir, "env.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("chain-id: osmosis-1\n"), 0o600))
+		t.Setenv("COMPASS_GAS_PRICES", "0.5uosmo")
+		t.Setenv("COMPASS_GAS_ADJUSTMENT", "1.5")
+		t.Setenv("COMPASS_DEBUG", "true")
+		t.Setenv("COMPASS_SLIP44", "118")
+		t.Setenv("COMPASS_EXTRA_CODECS", "ibc, wasm")
+		cfg, err := compass.LoadConfig(path)
+		require.NoError(t, err)
+		require.Equal(t, "0.5uosmo", cfg.GasPrices)
+		require.Equal(t, 1.5, cfg.GasAdjustment)
+		require.True(t, cfg.Debug)
+		require.Equal(t, 118, cfg.Slip44)
+		require.Equal(t, []string{"ibc", "wasm"}, cfg.ExtraCodecs)
+		require.Equal(t, "osmo", cfg.AccountPrefix)
+		requireCodec(t, cfg, "/ibc.applications.transfer.v1.MsgTransfer", "/cosmwasm.wasm.v1.MsgExecuteContract")
+
+		t.Setenv("COMPASS_GAS_ADJUSTMENT", "lots")
+		_, err = compass.LoadConfig(path)
+		require.Error(t, err)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		path := filepath.Join(dir, "invalid.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("chain-id: unknown-1\n"), 0o600))
+		_, err := compass.LoadConfig(path)
+		require.ErrorContains(t, err, "rpc-addr")
+		require.ErrorContains(t, err, "grpc-addr")
+		require.ErrorContains(t, err, "account-prefix")
+
+		_, err = compass.LoadConfig(filepath.Join(dir, "config.toml"))
+		require.Error(t, err)
+	})
+}
+
+// requires the codec of the config to resolve and amino encode the given message types
+func requireCodec(t *testing.T, cfg *compass.ClientConfig, typeURLs ...string) {
+	t.Helper()
+	var cdc compass.Codec
+	require.NotPanics(t, func() { cdc = compass.MakeCodec(cfg.Modules, cfg.ExtraCodecs) })
+	for _, typeURL := range typeURLs {
+		msg, err := cdc.InterfaceRegistry.Resolve(typeURL)
+		require.NoError(t, err, typeURL)
+		_, err = cdc.Amino.MarshalJSON(msg)
+		require.NoError(t, err, typeURL)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	require.NoError(t, compass.GetSimdConfig().Validate())
+	require.NoError(t, compass.GetCosmosHubConfig("home", false).Validate())
+	require.NoError(t, compass.GetOsmosisConfig("home", false).Validate())
+
+	cfg := compass.GetSimdConfig()
+	cfg.RPCAddr = "ftp://127.0.0.1"
+	cfg.GRPCAddr = "127.0.0.1"
+	cfg.GasPrices = "one-stake"
+	cfg.AccountPrefix = ""
+	cfg.Timeout = "soon"
+	cfg.ExtraCodecs = []string{"unknown"}
+	err := cfg.Validate()
+	for _, field := range []string{"rpc-addr", "grpc-addr", "gas-prices", "account-prefix", "timeout", "extra-codecs"} {
+		require.ErrorContains(t, err, field)
+	}
+}
@@ -0,0 +1,236 @@
+package compass
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	"github.com/cosmos/cosmos-sdk/client/grpc/cmtservice"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"go.uber.org/zap"
+	"golang.org/x/exp/slices"
+	"google.golang.org/grpc"
+)
+
+// maximum time spent verifying the chain ID of the node during initialization
+const chainIDCheckTimeout = 5 * time.Second
+
+// Returned when a node reports a chain ID which differs from the configured chain ID
+var ErrChainIDMismatch = errors.New("chain id mismatch")
+
+// Returned when a node reports a chain ID which differs from the configured chain ID
+type ChainIDMismatchError struct {
+	Endpoint string
+	Expected string
+	Actual   string
+}
+
+func (e *ChainIDMismatchError) Error() string {
+	return fmt.Sprintf("node %s reports chain id %q, expected %q", e.Endpoint, e.Actual, e.Expected)
+}
+
+func (e *ChainIDMismatchError) Is(target error) bool {
+	return target == ErrChainIDMismatch
+}
+
+// Rebuilds the RPC and gRPC transports without recreating the keyring. `RPCAddr` and `GRPCAddr` are tried
+// first followed by `RPCAddrs` and `GRPCAddrs` in order, using the first endpoints which are reachable and
+// report the configured chain ID. Subscriptions are resumed against the new RPC endpoint. If no usable
+// endpoint is found the current transports are left in place. Requests in flight complete before the
+// current transports are closed
+func (c *Client) Reconnect(ctx context.Context) error {
+	if err := c.checkClosed(); err != nil {
+		return err
+	}
+	// transactions being sent complete against the transports they started with
+	c.txLock.Lock()
+	defer c.txLock.Unlock()
+
+	rpc, transport, rpcAddr, err := c.connectRPC(ctx)
+	if err != nil {
+		return err
+	}
+	grpcConn, grpcAddr, err := c.connectGRPC(ctx)
+	if err != nil {
+		transport.CloseIdleConnections()
+		return err
+	}
+
+	c.transportLock.Lock()
+	c.releaseTransports()
+	c.setTransports(rpc, transport, rpcAddr, grpcConn)
+	c.transportLock.Unlock()
+
+	c.eventsLock.Lock()
+	if c.events != nil {
+		c.events.setRemote(rpcAddr)
+	}
+	c.eventsLock.Unlock()
+
+	if rpcAddr != c.cfg.RPCAddr {
+		c.cfg.Metrics.observeFailover("rpc")
+	}
+	if grpcAddr != c.cfg.GRPCAddr {
+		c.cfg.Metrics.observeFailover("grpc")
+	}
+	if rpcAddr != c.cfg.RPCAddr || grpcAddr != c.cfg.GRPCAddr {
+		c.log.Warn("failed over to alternate endpoints", zap.String("rpc.addr", rpcAddr), zap.String("grpc.addr", grpcAddr))
+	}
+	c.log.Info("reconnected client", zap.String("rpc.addr", rpcAddr), zap.String("grpc.addr", grpcAddr))
+	return nil
+}
+
+// returns an rpc client connected to the first usable rpc endpoint
+func (c *Client) connectRPC(ctx context.Context) (*rpchttp.HTTP, *closableTransport, string, error) {
+	var errs []error
+	for _, addr := range endpoints(c.cfg.RPCAddr, c.cfg.RPCAddrs) {
+		rpc, transport, err := c.dialRPC(addr)
+		if err == nil {
+			if err = c.verifyRPCChainID(ctx, rpc, addr); err == nil {
+				return rpc, transport, addr, nil
+			}
+			transport.CloseIdleConnections()
+		}
+		c.log.Warn("rpc endpoint unusable", zap.String("rpc.addr", addr), zap.Error(err))
+		errs = append(errs, err)
+	}
+	return nil, nil, "", fmt.Errorf("no usable rpc endpoint %w", errors.Join(errs...))
+}
+
+// returns a grpc connection to the first usable grpc endpoint
+func (c *Client) connectGRPC(ctx context.Context) (*grpc.ClientConn, string, error) {
+	var errs []error
+	for _, addr := range endpoints(c.cfg.GRPCAddr, c.cfg.GRPCAddrs) {
+		conn, err := c.dialGRPC(addr)
+		if err == nil {
+			if err = c.verifyGRPCChainID(ctx, conn, addr); err == nil {
+				return conn, addr, nil
+			}
+			_ = conn.Close()
+		}
+		c.log.Warn("grpc endpoint unusable", zap.String("grpc.addr", addr), zap.Error(err))
+		errs = append(errs, err)
+	}
+	return nil, "", fmt.Errorf("no usable grpc endpoint %w", errors.Join(errs...))
+}
+
+// returns an rpc client for the endpoint, whose requests fail once the client is closed
+func (c *Client) dialRPC(addr string) (*rpchttp.HTTP, *closableTransport, error) {
+	rpc, httpClient, err := newRPCClient(addr, time.Second*30)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to construct rpc client %v", err)
+	}
+	transport := &closableTransport{
+		RoundTripper: httpClient.Transport,
+		closed:       &c.transportsClosed,
+		inFlight:     &c.transportLock,
+		metrics:      c.cfg.Metrics,
+		tracer:       c.tracer(),
+		chainID:      c.cfg.ChainID,
+	}
+	httpClient.Transport = transport
+	return rpc, transport, nil
+}
+
+// returns a grpc connection to the endpoint, whose requests fail once the client is closed
+func (c *Client) dialGRPC(addr string) (*grpc.ClientConn, error) {
+	conn, err := grpc.Dial(
+		addr,
+		grpc.WithInsecure(), // The Cosmos SDK doesn't support any transport security mechanism
+		// the default codec can't marshal the customtype fields (ie: `math.Int`) of gogoproto types
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(codec.NewProtoCodec(c.Codec.InterfaceRegistry).GRPCCodec())),
+		grpc.WithChainUnaryInterceptor(c.unaryInterceptor),
+		grpc.WithChainStreamInterceptor(c.streamInterceptor),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial grpc server node %s", err)
+	}
+	return conn, nil
+}
+
+// closes the transports of the client, if any. The transport lock must be held
+func (c *Client) releaseTransports() {
+	if c.GRPC != nil {
+		if err := c.GRPC.Close(); err != nil {
+			c.log.Warn("failed to close grpc connection", zap.Error(err))
+		}
+	}
+	if c.rpcTransport != nil {
+		c.rpcTransport.CloseIdleConnections()
+	}
+	c.GRPC, c.DynamicTypes = nil, nil
+	c.RPC, c.rpcTransport, c.rpcAddr = nil, nil, ""
+}
+
+// replaces the transports of the client. The transport lock must be held
+func (c *Client) setTransports(rpc *rpchttp.HTTP, transport *closableTransport, rpcAddr string, grpcConn *grpc.ClientConn) {
+	c.RPC, c.rpcTransport, c.rpcAddr = rpc, transport, rpcAddr
+	c.GRPC = grpcConn
+	c.DynamicTypes = NewDynamicTypes(grpcConn)
+}
+
+// returns the rpc client currently in use
+func (c *Client) rpcClient() *rpchttp.HTTP {
+	c.transportLock.RLock()
+	defer c.transportLock.RUnlock()
+	return c.RPC
+}
+
+// returns the grpc connection currently in use
+func (c *Client) grpcConn() *grpc.ClientConn {
+	c.transportLock.RLock()
+	defer c.transportLock.RUnlock()
+	return c.GRPC
+}
+
+// returns the types resolved through the grpc connection currently in use
+func (c *Client) dynamicTypes() *DynamicTypes {
+	c.transportLock.RLock()
+	defer c.transportLock.RUnlock()
+	return c.DynamicTypes
+}
+
+// returns the rpc endpoint currently in use
+func (c *Client) rpcEndpoint() string {
+	c.transportLock.RLock()
+	defer c.transportLock.RUnlock()
+	return c.rpcAddr
+}
+
+// fails if the chain ID reported by the rpc endpoint differs from the configured chain ID
+func (c *Client) verifyRPCChainID(ctx context.Context, rpc *rpchttp.HTTP, addr string) error {
+	status, err := rpc.Status(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to query status of %s %w", addr, err)
+	}
+	return c.verifyChainID(addr, status.NodeInfo.Network)
+}
+
+// fails if the chain ID reported by the grpc endpoint differs from the configured chain ID
+func (c *Client) verifyGRPCChainID(ctx context.Context, conn *grpc.ClientConn, addr string) error {
+	res, err := cmtservice.NewServiceClient(conn).GetNodeInfo(ctx, &cmtservice.GetNodeInfoRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to query node info of %s %w", addr, err)
+	}
+	return c.verifyChainID(addr, res.DefaultNodeInfo.Network)
+}
+
+func (c *Client) verifyChainID(addr, chainID string) error {
+	if chainID != c.cfg.ChainID {
+		return &ChainIDMismatchError{Endpoint: addr, Expected: c.cfg.ChainID, Actual: chainID}
+	}
+	return nil
+}
+
+// returns the primary endpoint followed by the distinct alternate endpoints
+func endpoints(primary string, alternates []string) []string {
+	addrs := []string{primary}
+	for _, addr := range alternates {
+		if addr != "" && !slices.Contains(addrs, addr) {
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs
+}
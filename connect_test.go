@@ -0,0 +1,156 @@
+package compass_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/require"
+	"github.com/teamscanworks/compass"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+func TestInitializeChainIDMismatch(t *testing.T) {
+	logger, err := zap.NewDevelopment()
+	require.NoError(t, err)
+	node := newFakeNode(t, "other")
+	cfg := newTestConfig(node.srv.URL, "127.0.0.1:1")
+
+	client, err := compass.NewClient(logger, cfg, []keyring.Option{compass.DefaultSignatureOptions()})
+	require.ErrorIs(t, err, compass.ErrChainIDMismatch)
+	require.Nil(t, client.RPC)
+	require.Nil(t, client.GRPC)
+	require.Nil(t, client.Keyring)
+
+	// initialization may be retried once the node is fixed
+	node.mu.Lock()
+	node.chainID = cfg.ChainID
+	node.mu.Unlock()
+	require.NoError(t, client.Initialize([]keyring.Option{compass.DefaultSignatureOptions()}))
+	require.NotNil(t, client.RPC)
+	require.NoError(t, client.Close(context.Background()))
+}
+
+func TestReconnect(t *testing.T) {
+	logger, err := zap.NewDevelopment()
+	require.NoError(t, err)
+	node := newFakeNode(t, "testing")
+	wrongNode := newFakeNode(t, "other")
+	// the primary endpoints are unreachable, so the client fails over to the alternates
+	cfg := newTestConfig("http://127.0.0.1:1", "127.0.0.1:1")
+	cfg.RPCAddrs = []string{wrongNode.srv.URL, node.srv.URL}
+	cfg.GRPCAddrs = []string{newNodeInfoServer(t, "other"), newNodeInfoServer(t, "testing")}
+
+	client, err := compass.NewClient(logger, cfg, []keyring.Option{compass.DefaultSignatureOptions()})
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close(context.Background()) })
+	_, err = client.AddKey("default", 118)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, client.Reconnect(ctx))
+	status, err := client.RPC.Status(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "testing", status.NodeInfo.Network)
+	require.True(t, client.KeyExists("default"))
+
+	// without a usable endpoint the current transports are kept
+	rpc := client.RPC
+	cfg.RPCAddrs = cfg.RPCAddrs[:1]
+	require.ErrorIs(t, client.Reconnect(ctx), compass.ErrChainIDMismatch)
+	require.Equal(t, rpc, client.RPC)
+}
+
+func TestNodeHealth(t *testing.T) {
+	logger, err := zap.NewDevelopment()
+	require.NoError(t, err)
+	node := newFakeNode(t, "testing")
+	node.catchingUp = true
+	client, err := compass.NewClient(logger, newTestConfig(node.srv.URL, "127.0.0.1:1"), []keyring.Option{compass.DefaultSignatureOptions()})
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close(context.Background()) })
+	ctx := context.Background()
+
+	health, err := client.NodeHealth(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "testing", health.ChainID)
+	require.Equal(t, "0.38.0", health.NodeVersion)
+	require.Equal(t, "v1.2.3", health.AppVersion)
+	require.Equal(t, int64(1), health.LatestHeight)
+	require.True(t, health.CatchingUp)
+	require.Equal(t, 3, health.Peers)
+	require.GreaterOrEqual(t, health.Lag, time.Minute)
+
+	// the node catches up and produces blocks while waiting
+	go func() {
+		time.Sleep(time.Second)
+		node.mu.Lock()
+		node.catchingUp = false
+		node.height = 5
+		node.mu.Unlock()
+	}()
+	waitCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	require.NoError(t, client.WaitForSync(waitCtx))
+	require.NoError(t, client.WaitForHeight(waitCtx, 5))
+
+	shortCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	require.ErrorIs(t, client.WaitForHeight(shortCtx, 6), context.DeadlineExceeded)
+}
+
+func TestReconnectConcurrentQueries(t *testing.T) {
+	logger, err := zap.NewDevelopment()
+	require.NoError(t, err)
+	node := newFakeNode(t, "testing")
+	cfg := newTestConfig(node.srv.URL, newNodeInfoServer(t, "testing", func(srv *grpc.Server) {
+		banktypes.RegisterQueryServer(srv, &balanceService{balances: sdk.NewCoins(sdk.NewInt64Coin("stake", 1_000_000))})
+	}))
+	client, err := compass.NewClient(logger, cfg, []keyring.Option{compass.DefaultSignatureOptions()})
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close(context.Background()) })
+	_, err = client.AddKey("default", 118)
+	require.NoError(t, err)
+	require.NoError(t, client.SetFromAddress())
+	ctx := context.Background()
+
+	// rpc and grpc queries made while reconnecting complete against either transports
+	done := make(chan struct{})
+	errs := make(chan error, 4)
+	var wg sync.WaitGroup
+	for i := 0; i < cap(errs); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				if _, err := client.NodeHealth(ctx); err != nil {
+					errs <- err
+					return
+				}
+				if _, err := client.SelectGasPrice(ctx, 100_000); err != nil {
+					errs <- err
+					return
+				}
+			}
+		}()
+	}
+	for i := 0; i < 10; i++ {
+		require.NoError(t, client.Reconnect(ctx))
+	}
+	close(done)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+}
@@ -1,12 +1,23 @@
 package compass
 
 import (
+	"fmt"
+	"sort"
+	"sync"
+
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/codec/types"
 	"github.com/cosmos/cosmos-sdk/std"
 	"github.com/cosmos/cosmos-sdk/types/module"
 	"github.com/cosmos/cosmos-sdk/x/auth/tx"
+	"github.com/cosmos/cosmos-sdk/x/auth/vesting"
+	"github.com/cosmos/cosmos-sdk/x/consensus"
+	group "github.com/cosmos/cosmos-sdk/x/group/module"
+
+	"github.com/teamscanworks/compass/types/ibc"
+	"github.com/teamscanworks/compass/types/osmosis"
+	"github.com/teamscanworks/compass/types/wasm"
 )
 
 type Codec struct {
@@ -16,6 +27,105 @@ type Codec struct {
 	Amino             *codec.LegacyAmino
 }
 
+// Registers the types of a codec bundle against the interface registry and amino codec
+type CodecRegistrar func(registry types.InterfaceRegistry, amino *codec.LegacyAmino)
+
+var (
+	codecBundlesLock sync.RWMutex
+	// codec bundles which may be enabled through `ClientConfig.ExtraCodecs`. There is no ethermint bundle,
+	// as ethermint doesn't support the sdk version compass is built against; applications may register their
+	// own through `RegisterCodecBundle`
+	codecBundles = map[string]CodecRegistrar{
+		"consensus": ModuleCodecBundle(consensus.AppModuleBasic{}),
+		"group":     ModuleCodecBundle(group.AppModuleBasic{}),
+		"ibc":       ModuleCodecBundle(ibc.AppModuleBasic{}),
+		"osmosis":   ModuleCodecBundle(osmosis.AppModuleBasic{}),
+		"vesting":   ModuleCodecBundle(vesting.AppModuleBasic{}),
+		"wasm":      ModuleCodecBundle(wasm.AppModuleBasic{}),
+	}
+)
+
+// Registers a named codec bundle which can be enabled through `ClientConfig.ExtraCodecs`,
+// replacing any previously registered bundle of the same name. This allows applications to
+// make the types of their own modules available to compass.
+//
+// Bundles must be registered before the client is created
+func RegisterCodecBundle(name string, registrar CodecRegistrar) {
+	codecBundlesLock.Lock()
+	defer codecBundlesLock.Unlock()
+	codecBundles[name] = registrar
+}
+
+// Returns a registrar which registers the types of the given modules. Modules whose types are
+// already known to the registry, such as those included in the client's module basics, are skipped,
+// as registering their amino types twice panics
+func ModuleCodecBundle(modules ...module.AppModuleBasic) CodecRegistrar {
+	return func(registry types.InterfaceRegistry, amino *codec.LegacyAmino) {
+		var pending []module.AppModuleBasic
+		for _, m := range modules {
+			if !moduleRegistered(registry, m) {
+				pending = append(pending, m)
+			}
+		}
+		modBasic := module.NewBasicManager(pending...)
+		modBasic.RegisterLegacyAminoCodec(amino)
+		modBasic.RegisterInterfaces(registry)
+	}
+}
+
+// returns true if every implementation registered by the module is already known to the registry
+func moduleRegistered(registry types.InterfaceRegistry, m module.AppModuleBasic) bool {
+	// implementations are only listed for known interfaces, so the scratch registry starts off with those of the sdk
+	scratch := types.NewInterfaceRegistry()
+	std.RegisterInterfaces(scratch)
+	stdTypes := make(map[string]bool)
+	for _, iface := range scratch.ListAllInterfaces() {
+		for _, typeURL := range scratch.ListImplementations(iface) {
+			stdTypes[typeURL] = true
+		}
+	}
+	m.RegisterInterfaces(scratch)
+	registered := false
+	for _, iface := range scratch.ListAllInterfaces() {
+		for _, typeURL := range scratch.ListImplementations(iface) {
+			if stdTypes[typeURL] {
+				continue
+			}
+			if _, err := registry.Resolve(typeURL); err != nil {
+				return false
+			}
+			registered = true
+		}
+	}
+	return registered
+}
+
+// Returns the sorted names of all registered codec bundles
+func CodecBundles() []string {
+	codecBundlesLock.RLock()
+	defer codecBundlesLock.RUnlock()
+	names := make([]string, 0, len(codecBundles))
+	for name := range codecBundles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Returns an error if any of the named codec bundles has not been registered
+func ValidateCodecBundles(names []string) error {
+	codecBundlesLock.RLock()
+	defer codecBundlesLock.RUnlock()
+	for _, name := range names {
+		if _, ok := codecBundles[name]; !ok {
+			return fmt.Errorf("unknown codec bundle %q", name)
+		}
+	}
+	return nil
+}
+
+// Returns a codec registering the types of the given modules, as well as those of the named
+// codec bundles. Unknown bundles are ignored, and can be detected with `ValidateCodecBundles`
 func MakeCodec(moduleBasics []module.AppModuleBasic, extraCodecs []string) Codec {
 	modBasic := module.NewBasicManager(moduleBasics...)
 	encodingConfig := MakeCodecConfig()
@@ -24,6 +134,14 @@ func MakeCodec(moduleBasics []module.AppModuleBasic, extraCodecs []string) Codec
 	modBasic.RegisterLegacyAminoCodec(encodingConfig.Amino)
 	modBasic.RegisterInterfaces(encodingConfig.InterfaceRegistry)
 
+	codecBundlesLock.RLock()
+	defer codecBundlesLock.RUnlock()
+	for _, name := range extraCodecs {
+		if registrar, ok := codecBundles[name]; ok {
+			registrar(encodingConfig.InterfaceRegistry, encodingConfig.Amino)
+		}
+	}
+
 	return encodingConfig
 }
 
@@ -0,0 +1,123 @@
+package compass_test
+
+import (
+	"context"
+	"testing"
+
+	"cosmossdk.io/x/tx/signing"
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authcodec "github.com/cosmos/cosmos-sdk/x/auth/codec"
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/stretchr/testify/require"
+	"github.com/teamscanworks/compass"
+	"github.com/teamscanworks/compass/types/feegrant"
+	"github.com/teamscanworks/compass/types/ibc/transfer"
+	"go.uber.org/zap"
+)
+
+// returns a codec whose signing context is able to decode cosmos prefixed addresses
+func newSigningCodec(t *testing.T, register func(codectypes.InterfaceRegistry)) *codec.ProtoCodec {
+	registry, err := codectypes.NewInterfaceRegistryWithOptions(codectypes.InterfaceRegistryOptions{
+		ProtoFiles: proto.HybridResolver,
+		SigningOptions: signing.Options{
+			AddressCodec:          authcodec.NewBech32Codec("cosmos"),
+			ValidatorAddressCodec: authcodec.NewBech32Codec("cosmosvaloper"),
+		},
+	})
+	require.NoError(t, err)
+	register(registry)
+	return codec.NewProtoCodec(registry)
+}
+
+func TestMakeCodecFeegrant(t *testing.T) {
+	cdc := compass.MakeCodec(compass.ModuleBasics, []string{})
+	for _, msg := range []sdk.Msg{&feegrant.MsgGrantAllowance{}, &feegrant.MsgRevokeAllowance{}} {
+		resolved, err := cdc.InterfaceRegistry.Resolve(sdk.MsgTypeURL(msg))
+		require.NoError(t, err)
+		require.IsType(t, msg, resolved)
+	}
+
+	signer := sdk.AccAddress([]byte("granter_address_____"))
+	allowance, err := codectypes.NewAnyWithValue(&feegrant.BasicAllowance{})
+	require.NoError(t, err)
+	msg := &feegrant.MsgGrantAllowance{
+		Granter:   sdk.MustBech32ifyAddressBytes("cosmos", signer),
+		Grantee:   sdk.MustBech32ifyAddressBytes("cosmos", []byte("grantee_address_____")),
+		Allowance: allowance,
+	}
+	signers, _, err := newSigningCodec(t, feegrant.RegisterInterfaces).GetMsgV1Signers(msg)
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{signer}, signers)
+
+	// round trip through an any to ensure the generated types are wire compatible
+	packed, err := codectypes.NewAnyWithValue(msg)
+	require.NoError(t, err)
+	var unpacked sdk.Msg
+	require.NoError(t, cdc.InterfaceRegistry.UnpackAny(packed, &unpacked))
+	require.Equal(t, msg.Granter, unpacked.(*feegrant.MsgGrantAllowance).Granter)
+}
+
+func TestCodecBundles(t *testing.T) {
+	require.Subset(t, compass.CodecBundles(), []string{"consensus", "group", "ibc", "vesting"})
+	require.Error(t, compass.ValidateCodecBundles([]string{"group", "unknown"}))
+
+	// types of bundles are only registered when enabled
+	vestingURL := "/cosmos.vesting.v1beta1.MsgCreateVestingAccount"
+	_, err := compass.MakeCodec(compass.ModuleBasics, nil).InterfaceRegistry.Resolve(vestingURL)
+	require.Error(t, err)
+	_, err = compass.MakeCodec(compass.ModuleBasics, []string{"vesting"}).InterfaceRegistry.Resolve(vestingURL)
+	require.NoError(t, err)
+
+	// bundles of modules already included in the module basics are skipped rather than registered twice
+	transferURL := "/ibc.applications.transfer.v1.MsgTransfer"
+	for _, extraCodecs := range [][]string{{"ibc"}, {"osmosis", "wasm", "ibc"}, compass.CodecBundles()} {
+		var cdc compass.Codec
+		require.NotPanics(t, func() { cdc = compass.MakeCodec(compass.ModuleBasics, extraCodecs) })
+		_, err = cdc.InterfaceRegistry.Resolve(transferURL)
+		require.NoError(t, err)
+	}
+	_, err = compass.MakeCodec(nil, []string{"ibc"}).InterfaceRegistry.Resolve(transferURL)
+	require.NoError(t, err)
+
+	t.Run("Custom", func(t *testing.T) {
+		registered := false
+		compass.RegisterCodecBundle("custom-test", func(registry codectypes.InterfaceRegistry, amino *codec.LegacyAmino) {
+			feegrant.RegisterInterfaces(registry)
+			registered = true
+		})
+		require.Contains(t, compass.CodecBundles(), "custom-test")
+		compass.MakeCodec(nil, []string{"custom-test"})
+		require.True(t, registered)
+
+		cfg := compass.GetSimdConfig()
+		cfg.KeyringBackend = "memory"
+		cfg.ExtraCodecs = []string{"custom-test"}
+		require.NoError(t, cfg.Validate())
+		cfg.ExtraCodecs = []string{"missing-test"}
+		_, err := compass.NewClient(zap.NewNop(), cfg, nil)
+		require.Error(t, err)
+	})
+}
+
+func TestCodecBundlesClient(t *testing.T) {
+	node := newFakeNode(t, "testing")
+	cfg := newTestConfig(node.srv.URL, newNodeInfoServer(t, "testing"))
+	cfg.ExtraCodecs = []string{"osmosis", "wasm", "ibc"}
+	client, err := compass.NewClient(zap.NewNop(), cfg, []keyring.Option{compass.DefaultSignatureOptions()})
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close(context.Background()) })
+
+	for _, typeURL := range []string{
+		"/ibc.applications.transfer.v1.MsgTransfer",
+		"/cosmwasm.wasm.v1.MsgExecuteContract",
+		"/osmosis.poolmanager.v1beta1.MsgSwapExactAmountIn",
+	} {
+		_, err := client.Codec.InterfaceRegistry.Resolve(typeURL)
+		require.NoError(t, err, typeURL)
+	}
+	_, err = client.Codec.Amino.MarshalJSON(&transfer.MsgTransfer{SourcePort: "transfer"})
+	require.NoError(t, err)
+}
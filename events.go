@@ -0,0 +1,524 @@
+package compass
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	jsonrpcclient "github.com/cometbft/cometbft/rpc/jsonrpc/client"
+	comettypes "github.com/cometbft/cometbft/types"
+	"go.uber.org/zap"
+)
+
+const (
+	// number of events buffered per subscription, once full the subscription is resynced through rpc
+	eventBufferSize = 100
+	// bounds of the backoff between websocket reconnection attempts
+	minReconnectBackoff = time.Second
+	maxReconnectBackoff = 30 * time.Second
+)
+
+// Subscribes to new blocks. Blocks missed while the websocket was disconnected are back-filled through rpc,
+// such that blocks are delivered in order without gaps. The channel is closed once the context is cancelled
+// or the client is closed
+func (c *Client) SubscribeNewBlocks(ctx context.Context) (<-chan comettypes.EventDataNewBlock, error) {
+	return subscribe(ctx, c, comettypes.EventQueryNewBlock.String(), func(event coretypes.ResultEvent) (comettypes.EventDataNewBlock, bool) {
+		data, ok := event.Data.(comettypes.EventDataNewBlock)
+		return data, ok
+	})
+}
+
+// Subscribes to transactions matching the query (ie: `message.sender='cosmos1...'`), with an empty query
+// matching all transactions. Transactions missed while the websocket was disconnected are back-filled
+// through `tx_search`, which requires the node to index transactions. The channel is closed once the
+// context is cancelled or the client is closed
+func (c *Client) SubscribeTxs(ctx context.Context, query string) (<-chan comettypes.EventDataTx, error) {
+	fullQuery := comettypes.EventQueryTx.String()
+	if query != "" {
+		fullQuery += " AND " + query
+	}
+	return subscribe(ctx, c, fullQuery, func(event coretypes.ResultEvent) (comettypes.EventDataTx, bool) {
+		data, ok := event.Data.(comettypes.EventDataTx)
+		return data, ok
+	})
+}
+
+// Subscribes to events matching the query. The websocket is connected by the first subscription, and is
+// reconnected and resubscribed whenever it drops. Events missed while disconnected are back-filled for
+// `NewBlock` and `Tx` queries only. The channel is closed once the context is cancelled or the client is closed
+func (c *Client) Subscribe(ctx context.Context, query string) (<-chan coretypes.ResultEvent, error) {
+	return subscribe(ctx, c, query, func(event coretypes.ResultEvent) (coretypes.ResultEvent, bool) {
+		return event, true
+	})
+}
+
+// subscribes to the query, delivering events converted to T
+func subscribe[T any](ctx context.Context, c *Client, query string, convert func(coretypes.ResultEvent) (T, bool)) (<-chan T, error) {
+	out := make(chan T)
+	sub := &subscription{
+		query:  query,
+		events: make(chan coretypes.ResultEvent, eventBufferSize),
+		resync: make(chan struct{}, 1),
+		deliver: func(ctx context.Context, quit <-chan struct{}, event coretypes.ResultEvent) bool {
+			data, ok := convert(event)
+			if !ok {
+				return true
+			}
+			select {
+			case out <- data:
+				return true
+			case <-ctx.Done():
+				return false
+			case <-quit:
+				return false
+			}
+		},
+		close: func() { close(out) },
+	}
+	switch {
+	case strings.Contains(query, comettypes.EventQueryNewBlock.String()):
+		sub.kind = subscriptionBlocks
+	case strings.Contains(query, comettypes.EventQueryTx.String()):
+		sub.kind = subscriptionTxs
+		// transactions are back-filled from the height at which the subscription started
+		status, err := c.rpcClient().Status(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query node status %s", err)
+		}
+		sub.nextTxHeight = status.SyncInfo.LatestBlockHeight
+	}
+	stream, err := c.eventStream(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.add(ctx, sub); err != nil {
+		return nil, err
+	}
+	go sub.run(ctx, c, stream)
+	return out, nil
+}
+
+// returns the event stream of the client, connecting the websocket on first use
+func (c *Client) eventStream(ctx context.Context) (*eventStream, error) {
+	if err := c.checkClosed(); err != nil {
+		return nil, err
+	}
+	c.eventsLock.Lock()
+	defer c.eventsLock.Unlock()
+	if c.events != nil {
+		return c.events, nil
+	}
+	stream := &eventStream{
+		log:    c.log.Named("events"),
+		remote: c.rpcEndpoint(),
+		subs:   make(map[string][]*subscription),
+		quit:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	ws, err := stream.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	go stream.run(ws)
+	c.events = stream
+	return stream, nil
+}
+
+// stops the event stream of the client, if it was started
+func (c *Client) stopEvents() {
+	c.eventsLock.Lock()
+	defer c.eventsLock.Unlock()
+	if c.events != nil {
+		c.events.stop()
+		c.events = nil
+	}
+}
+
+// A websocket connection multiplexing the subscriptions of the client
+type eventStream struct {
+	log    *zap.Logger
+	remote string
+
+	mu   sync.Mutex
+	ws   *jsonrpcclient.WSClient
+	subs map[string][]*subscription
+
+	reconnected chan struct{}
+	quit        chan struct{}
+	done        chan struct{}
+	stopOnce    sync.Once
+}
+
+// dials the websocket, subscribing to the queries of all subscriptions
+func (s *eventStream) connect(ctx context.Context) (*jsonrpcclient.WSClient, error) {
+	reconnected := make(chan struct{}, 1)
+	s.mu.Lock()
+	remote := s.remote
+	s.mu.Unlock()
+	// the websocket client reconnects a single time, after which the stream redials it
+	ws, err := jsonrpcclient.NewWS(remote, "/websocket",
+		jsonrpcclient.MaxReconnectAttempts(1),
+		jsonrpcclient.OnReconnect(func() {
+			select {
+			case reconnected <- struct{}{}:
+			default:
+			}
+		}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct websocket client %s", err)
+	}
+	if err := ws.Start(); err != nil {
+		return nil, fmt.Errorf("failed to connect websocket %s", err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for query := range s.subs {
+		if err := ws.Subscribe(ctx, query); err != nil {
+			_ = ws.Stop()
+			return nil, fmt.Errorf("failed to subscribe to %q %s", query, err)
+		}
+	}
+	s.ws = ws
+	s.reconnected = reconnected
+	return ws, nil
+}
+
+// dispatches events to subscriptions, redialing the websocket with backoff whenever it stops
+func (s *eventStream) run(ws *jsonrpcclient.WSClient) {
+	defer close(s.done)
+	for {
+		if !s.read(ws) {
+			_ = ws.Stop()
+			return
+		}
+		s.log.Warn("websocket disconnected, reconnecting")
+		backoff := minReconnectBackoff
+		for {
+			ctx, cancel := context.WithTimeout(context.Background(), maxReconnectBackoff)
+			var err error
+			ws, err = s.connect(ctx)
+			cancel()
+			if err == nil {
+				break
+			}
+			s.log.Warn("failed to reconnect websocket", zap.Error(err), zap.Duration("backoff", backoff))
+			select {
+			case <-s.quit:
+				return
+			case <-time.After(backoff):
+			}
+			if backoff *= 2; backoff > maxReconnectBackoff {
+				backoff = maxReconnectBackoff
+			}
+		}
+		s.log.Info("websocket reconnected")
+		s.resyncAll()
+	}
+}
+
+// reads responses until the websocket stops, returning false if the stream was stopped
+func (s *eventStream) read(ws *jsonrpcclient.WSClient) bool {
+	s.mu.Lock()
+	reconnected := s.reconnected
+	s.mu.Unlock()
+	for {
+		select {
+		case <-s.quit:
+			return false
+		case <-reconnected:
+			// the websocket client redialed the node, so subscriptions must be renewed
+			s.mu.Lock()
+			for query := range s.subs {
+				if err := ws.Subscribe(context.Background(), query); err != nil {
+					s.log.Warn("failed to resubscribe", zap.String("query", query), zap.Error(err))
+				}
+			}
+			s.mu.Unlock()
+			s.resyncAll()
+		case res, ok := <-ws.ResponsesCh:
+			if !ok {
+				return true
+			}
+			if res.Error != nil {
+				s.log.Warn("websocket error", zap.Error(res.Error))
+				continue
+			}
+			var event coretypes.ResultEvent
+			if err := cmtjson.Unmarshal(res.Result, &event); err != nil {
+				s.log.Warn("failed to decode event", zap.Error(err))
+				continue
+			}
+			if event.Query == "" {
+				// response to a subscription request
+				continue
+			}
+			s.mu.Lock()
+			for _, sub := range s.subs[event.Query] {
+				select {
+				case sub.events <- event:
+				default:
+					// the subscriber is lagging, so the dropped events are back-filled once it catches up
+					sub.requestResync()
+				}
+			}
+			s.mu.Unlock()
+		}
+	}
+}
+
+// registers the subscription, subscribing to its query if no other subscription shares it
+func (s *eventStream) add(ctx context.Context, sub *subscription) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	select {
+	case <-s.quit:
+		return ErrClosed
+	default:
+	}
+	if len(s.subs[sub.query]) == 0 {
+		if err := s.ws.Subscribe(ctx, sub.query); err != nil {
+			return fmt.Errorf("failed to subscribe to %q %s", sub.query, err)
+		}
+	}
+	s.subs[sub.query] = append(s.subs[sub.query], sub)
+	return nil
+}
+
+// removes the subscription, unsubscribing from its query if no other subscription shares it
+func (s *eventStream) remove(sub *subscription) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	subs := s.subs[sub.query]
+	for i, existing := range subs {
+		if existing == sub {
+			subs = append(subs[:i], subs[i+1:]...)
+			break
+		}
+	}
+	if len(subs) > 0 {
+		s.subs[sub.query] = subs
+		return
+	}
+	delete(s.subs, sub.query)
+	if s.ws.IsRunning() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := s.ws.Unsubscribe(ctx, sub.query); err != nil {
+			s.log.Debug("failed to unsubscribe", zap.String("query", sub.query), zap.Error(err))
+		}
+	}
+}
+
+// moves the stream to another rpc endpoint, dropping the current websocket such that it is redialed
+func (s *eventStream) setRemote(remote string) {
+	s.mu.Lock()
+	s.remote = remote
+	ws := s.ws
+	s.mu.Unlock()
+	_ = ws.Stop()
+}
+
+// requests all subscriptions to back-fill the events they may have missed
+func (s *eventStream) resyncAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, subs := range s.subs {
+		for _, sub := range subs {
+			sub.requestResync()
+		}
+	}
+}
+
+// stops the stream, closing the channels of all subscriptions
+func (s *eventStream) stop() {
+	s.stopOnce.Do(func() {
+		close(s.quit)
+		<-s.done
+	})
+}
+
+type subscriptionKind int
+
+const (
+	subscriptionCustom subscriptionKind = iota
+	subscriptionBlocks
+	subscriptionTxs
+)
+
+type subscription struct {
+	query string
+	kind  subscriptionKind
+	// height of the latest block delivered
+	lastHeight int64
+	// position of the next transaction to deliver, as its height and index within the block. Transactions
+	// before it have been delivered, either live or by a back-fill
+	nextTxHeight int64
+	nextTxIndex  uint32
+
+	events  chan coretypes.ResultEvent
+	resync  chan struct{}
+	deliver func(ctx context.Context, quit <-chan struct{}, event coretypes.ResultEvent) bool
+	close   func()
+}
+
+func (sub *subscription) requestResync() {
+	select {
+	case sub.resync <- struct{}{}:
+	default:
+	}
+}
+
+// delivers events until the context is cancelled or the stream is stopped
+func (sub *subscription) run(ctx context.Context, c *Client, stream *eventStream) {
+	defer sub.close()
+	defer stream.remove(sub)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-stream.quit:
+			return
+		case <-sub.resync:
+			if err := sub.backfill(ctx, c, stream.quit); err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				stream.log.Warn("failed to back-fill events", zap.String("query", sub.query), zap.Error(err))
+			}
+		case event := <-sub.events:
+			if !sub.handle(ctx, c, stream.quit, event) {
+				return
+			}
+		}
+	}
+}
+
+// delivers a live event, back-filling any blocks skipped since the last one
+func (sub *subscription) handle(ctx context.Context, c *Client, quit <-chan struct{}, event coretypes.ResultEvent) bool {
+	switch data := event.Data.(type) {
+	case comettypes.EventDataNewBlock:
+		if sub.kind != subscriptionBlocks {
+			break
+		}
+		height := data.Block.Height
+		if height <= sub.lastHeight {
+			return true
+		}
+		if sub.lastHeight > 0 && height > sub.lastHeight+1 {
+			if err := sub.backfillBlocks(ctx, c, quit, height-1); err != nil {
+				c.log.Warn("failed to back-fill blocks", zap.Error(err))
+			}
+		}
+		sub.lastHeight = height
+	case comettypes.EventDataTx:
+		if sub.kind != subscriptionTxs {
+			break
+		}
+		if !sub.txPending(data.Height, data.Index) {
+			return true
+		}
+		if !sub.deliver(ctx, quit, event) {
+			return false
+		}
+		sub.nextTxHeight, sub.nextTxIndex = data.Height, data.Index+1
+		return true
+	}
+	return sub.deliver(ctx, quit, event)
+}
+
+// returns true if the transaction at the given position has not been delivered yet
+func (sub *subscription) txPending(height int64, index uint32) bool {
+	return height > sub.nextTxHeight || (height == sub.nextTxHeight && index >= sub.nextTxIndex)
+}
+
+// delivers the events which occurred between the last delivered event and the latest block
+func (sub *subscription) backfill(ctx context.Context, c *Client, quit <-chan struct{}) error {
+	if sub.kind == subscriptionCustom || (sub.lastHeight == 0 && sub.nextTxHeight == 0) {
+		return nil
+	}
+	status, err := c.rpcClient().Status(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to query node status %s", err)
+	}
+	latest := status.SyncInfo.LatestBlockHeight
+	if sub.kind == subscriptionBlocks {
+		return sub.backfillBlocks(ctx, c, quit, latest)
+	}
+	if latest < sub.nextTxHeight {
+		return nil
+	}
+	// the block of the last delivered transaction is searched again, as it may include further transactions
+	query := fmt.Sprintf("%s AND tx.height >= %d AND tx.height <= %d", sub.query, sub.nextTxHeight, latest)
+	it := c.SearchTxs(query)
+	for it.Next(ctx) {
+		tx := it.Value()
+		if !sub.txPending(tx.Height, tx.Index) {
+			continue
+		}
+		if !sub.deliver(ctx, quit, coretypes.ResultEvent{
+			Query: sub.query,
+			Data:  comettypes.EventDataTx{TxResult: abciTxResult(tx)},
+		}) {
+			return ctx.Err()
+		}
+		sub.nextTxHeight, sub.nextTxIndex = tx.Height, tx.Index+1
+	}
+	if err := it.Err(); err != nil {
+		return err
+	}
+	sub.nextTxHeight, sub.nextTxIndex = latest+1, 0
+	return nil
+}
+
+// delivers the blocks following the last delivered block up to and including the given height
+func (sub *subscription) backfillBlocks(ctx context.Context, c *Client, quit <-chan struct{}, to int64) error {
+	for height := sub.lastHeight + 1; height <= to; height++ {
+		block, err := c.rpcClient().Block(ctx, &height)
+		if err != nil {
+			return fmt.Errorf("failed to query block %d %s", height, err)
+		}
+		results, err := c.rpcClient().BlockResults(ctx, &height)
+		if err != nil {
+			return fmt.Errorf("failed to query block results %d %s", height, err)
+		}
+		if !sub.deliver(ctx, quit, coretypes.ResultEvent{
+			Query: sub.query,
+			Data:  newBlockEvent(block, results),
+		}) {
+			return ctx.Err()
+		}
+		sub.lastHeight = height
+	}
+	return nil
+}
+
+// returns the transaction result of a searched transaction, as included in a `Tx` event
+func abciTxResult(tx *SearchedTx) abci.TxResult {
+	return abci.TxResult{
+		Height: tx.Height,
+		Index:  tx.Index,
+		Tx:     tx.Raw,
+		Result: tx.Result,
+	}
+}
+
+// returns the `NewBlock` event of a block queried through rpc
+func newBlockEvent(block *coretypes.ResultBlock, results *coretypes.ResultBlockResults) comettypes.EventDataNewBlock {
+	return comettypes.EventDataNewBlock{
+		Block:   block.Block,
+		BlockID: block.BlockID,
+		ResultFinalizeBlock: abci.ResponseFinalizeBlock{
+			Events:                results.FinalizeBlockEvents,
+			TxResults:             results.TxsResults,
+			ValidatorUpdates:      results.ValidatorUpdates,
+			ConsensusParamUpdates: results.ConsensusParamUpdates,
+			AppHash:               results.AppHash,
+		},
+	}
+}
@@ -0,0 +1,112 @@
+package compass_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	comettypes "github.com/cometbft/cometbft/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/stretchr/testify/require"
+	"github.com/teamscanworks/compass"
+	"go.uber.org/zap"
+)
+
+func TestSubscribeNewBlocks(t *testing.T) {
+	node := newFakeNode(t, "testing")
+	logger, err := zap.NewDevelopment()
+	require.NoError(t, err)
+	cfg := compass.GetSimdConfig()
+	cfg.RPCAddr = node.srv.URL
+	cfg.KeyringBackend = keyring.BackendMemory
+	client, err := compass.NewClient(logger, cfg, []keyring.Option{compass.DefaultSignatureOptions()})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	blocks, err := client.SubscribeNewBlocks(ctx)
+	require.NoError(t, err)
+	next := func() int64 {
+		select {
+		case block, ok := <-blocks:
+			require.True(t, ok)
+			return block.Block.Height
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for block")
+			return 0
+		}
+	}
+	require.Equal(t, int64(1), next())
+
+	// blocks 2 and 3 are missed while disconnected, and back-filled once resubscribed
+	node.disconnect(4)
+	for height := int64(2); height <= 4; height++ {
+		require.Equal(t, height, next())
+	}
+
+	// closing the client closes subscriptions
+	require.NoError(t, client.Close(ctx))
+	select {
+	case _, ok := <-blocks:
+		require.False(t, ok)
+	case <-ctx.Done():
+		t.Fatal("subscription was not closed")
+	}
+}
+
+func TestSubscribeTxs(t *testing.T) {
+	node := newFakeNode(t, "testing")
+	client, err := compass.NewClient(zap.NewNop(), newTestConfig(node.srv.URL, "127.0.0.1:1"), []keyring.Option{compass.DefaultSignatureOptions()})
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close(context.Background()) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	txs, err := client.SubscribeTxs(ctx, "")
+	require.NoError(t, err)
+	next := func() comettypes.Tx {
+		select {
+		case tx, ok := <-txs:
+			require.True(t, ok)
+			return tx.Tx
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for transaction")
+			return nil
+		}
+	}
+	// waits for the websocket to be subscribed
+	require.Eventually(t, func() bool {
+		node.mu.Lock()
+		defer node.mu.Unlock()
+		return len(node.subs) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	node.commitTx(1, comettypes.Tx("live"), true)
+	require.Equal(t, comettypes.Tx("live"), next())
+
+	// transactions committed while disconnected are back-filled once resubscribed, including those in the
+	// block of the last delivered transaction, without delivering it again
+	node.disconnect(2)
+	node.commitTx(1, comettypes.Tx("missed-same-block"), false)
+	node.commitTx(2, comettypes.Tx("missed"), false)
+	require.Equal(t, comettypes.Tx("missed-same-block"), next())
+	require.Equal(t, comettypes.Tx("missed"), next())
+
+	// live events of transactions which were already back-filled are dropped
+	require.Eventually(t, func() bool {
+		node.mu.Lock()
+		defer node.mu.Unlock()
+		return len(node.subs) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	node.publishTx(2, 0, comettypes.Tx("missed"))
+	node.mu.Lock()
+	node.height = 3
+	node.mu.Unlock()
+	node.commitTx(3, comettypes.Tx("live-again"), true)
+	require.Equal(t, comettypes.Tx("live-again"), next())
+	select {
+	case tx := <-txs:
+		t.Fatalf("unexpected transaction %s", tx.Tx)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
@@ -0,0 +1,20 @@
+package compass
+
+// exposes internals to the tests of the compass_test package
+
+// records a signed transaction in the journal
+func (j *Journal) Record(entry *JournalEntry) error {
+	return j.record(entry)
+}
+
+// transitions a journaled transaction to the status
+func (j *Journal) Transition(hash string, status TxStatus) error {
+	return j.transition(hash, status, "", nil)
+}
+
+// overrides the detection of stdin being a terminal, returning a function restoring it
+func SetStdinIsTerminal(terminal bool) func() {
+	previous := stdinIsTerminal
+	stdinIsTerminal = func() bool { return terminal }
+	return func() { stdinIsTerminal = previous }
+}
@@ -0,0 +1,319 @@
+package compass_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtlog "github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/p2p"
+	p2pproto "github.com/cometbft/cometbft/proto/tendermint/p2p"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpcserver "github.com/cometbft/cometbft/rpc/jsonrpc/server"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	comettypes "github.com/cometbft/cometbft/types"
+	"github.com/cosmos/cosmos-sdk/client/grpc/cmtservice"
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/stretchr/testify/require"
+	"github.com/teamscanworks/compass"
+	"google.golang.org/grpc"
+)
+
+// a node serving blocks up to its height, which pushes the latest block to new block subscriptions
+type fakeNode struct {
+	mu         sync.Mutex
+	chainID    string
+	height     int64
+	catchingUp bool
+	// results of the transactions included by the node, keyed by hash
+	txs map[string]abci.ExecTxResult
+	// transactions in the mempool of the node
+	mempool []comettypes.Tx
+	// transactions included in blocks, keyed by height
+	blockTxs map[int64][]comettypes.Tx
+	// transactions broadcast to the node, in order
+	broadcasts []comettypes.Tx
+	// results of the transactions broadcast, keyed by their index in `broadcasts`, defaulting to success
+	broadcastResults map[int]abci.ExecTxResult
+	// websocket subscriptions, which are dropped on disconnect
+	subs  []fakeSubscription
+	conns []net.Conn
+	srv   *httptest.Server
+}
+
+type fakeSubscription struct {
+	conn  rpctypes.WSRPCConnection
+	req   rpctypes.RPCRequest
+	query string
+}
+
+func newFakeNode(t *testing.T, chainID string) *fakeNode {
+	node := &fakeNode{
+		chainID:  chainID,
+		height:   1,
+		txs:      make(map[string]abci.ExecTxResult),
+		blockTxs: make(map[int64][]comettypes.Tx),
+	}
+	funcs := map[string]*rpcserver.RPCFunc{
+		"subscribe":           rpcserver.NewWSRPCFunc(node.subscribe, "query"),
+		"unsubscribe":         rpcserver.NewWSRPCFunc(node.unsubscribe, "query"),
+		"status":              rpcserver.NewRPCFunc(node.status, ""),
+		"abci_info":           rpcserver.NewRPCFunc(node.abciInfo, ""),
+		"net_info":            rpcserver.NewRPCFunc(node.netInfo, ""),
+		"tx":                  rpcserver.NewRPCFunc(node.tx, "hash,prove"),
+		"unconfirmed_txs":     rpcserver.NewRPCFunc(node.unconfirmedTxs, "limit"),
+		"num_unconfirmed_txs": rpcserver.NewRPCFunc(node.numUnconfirmedTxs, ""),
+		"broadcast_tx_sync":   rpcserver.NewRPCFunc(node.broadcastTxSync, "tx"),
+		"block":               rpcserver.NewRPCFunc(node.block, "height"),
+		"block_results":       rpcserver.NewRPCFunc(node.blockResults, "height"),
+		"tx_search":           rpcserver.NewRPCFunc(node.txSearch, "query,prove,page,per_page,order_by"),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/websocket", rpcserver.NewWebsocketManager(funcs).WebsocketHandler)
+	rpcserver.RegisterRPCFuncs(mux, funcs, cmtlog.NewNopLogger())
+	node.srv = httptest.NewUnstartedServer(mux)
+	node.srv.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			node.mu.Lock()
+			node.conns = append(node.conns, conn)
+			node.mu.Unlock()
+		}
+	}
+	node.srv.Start()
+	t.Cleanup(node.srv.Close)
+	return node
+}
+
+// drops all connections, and produces blocks while clients are disconnected
+func (n *fakeNode) disconnect(height int64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.height = height
+	for _, conn := range n.conns {
+		_ = conn.Close()
+	}
+	n.conns, n.subs = nil, nil
+}
+
+func (n *fakeNode) latest() int64 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.height
+}
+
+func (n *fakeNode) subscribe(ctx *rpctypes.Context, query string) (*coretypes.ResultSubscribe, error) {
+	n.mu.Lock()
+	height := n.height
+	n.subs = append(n.subs, fakeSubscription{conn: ctx.WSConn, req: *ctx.JSONReq, query: query})
+	n.mu.Unlock()
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		_ = ctx.WSConn.WriteRPCResponse(context.Background(), rpctypes.NewRPCSuccessResponse(ctx.JSONReq.ID, &coretypes.ResultEvent{
+			Query: query,
+			Data:  comettypes.EventDataNewBlock{Block: fakeBlock(height)},
+		}))
+	}()
+	return &coretypes.ResultSubscribe{}, nil
+}
+
+func (n *fakeNode) unsubscribe(*rpctypes.Context, string) (*coretypes.ResultUnsubscribe, error) {
+	return &coretypes.ResultUnsubscribe{}, nil
+}
+
+func (n *fakeNode) status(*rpctypes.Context) (*coretypes.ResultStatus, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return &coretypes.ResultStatus{
+		NodeInfo: p2p.DefaultNodeInfo{Network: n.chainID, Version: "0.38.0"},
+		SyncInfo: coretypes.SyncInfo{
+			LatestBlockHeight: n.height,
+			LatestBlockTime:   time.Now().Add(-time.Minute),
+			CatchingUp:        n.catchingUp,
+		},
+	}, nil
+}
+
+func (n *fakeNode) abciInfo(*rpctypes.Context) (*coretypes.ResultABCIInfo, error) {
+	return &coretypes.ResultABCIInfo{Response: abci.ResponseInfo{Version: "v1.2.3"}}, nil
+}
+
+func (n *fakeNode) tx(_ *rpctypes.Context, hash []byte, _ bool) (*coretypes.ResultTx, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	result, ok := n.txs[fmt.Sprintf("%X", hash)]
+	if !ok {
+		return nil, fmt.Errorf("tx (%X) not found", hash)
+	}
+	return &coretypes.ResultTx{Hash: hash, Height: n.height, TxResult: result}, nil
+}
+
+// includes the transaction in a block, removing it from the mempool
+func (n *fakeNode) include(tx comettypes.Tx, result abci.ExecTxResult) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.txs[fmt.Sprintf("%X", tx.Hash())] = result
+	for i, pending := range n.mempool {
+		if bytes.Equal(pending, tx) {
+			n.mempool = append(n.mempool[:i], n.mempool[i+1:]...)
+			break
+		}
+	}
+}
+
+// includes the transaction in the block at the given height, publishing it to transaction subscriptions if live
+func (n *fakeNode) commitTx(height int64, tx comettypes.Tx, live bool) {
+	n.mu.Lock()
+	index := uint32(len(n.blockTxs[height]))
+	n.blockTxs[height] = append(n.blockTxs[height], tx)
+	n.txs[fmt.Sprintf("%X", tx.Hash())] = abci.ExecTxResult{}
+	n.mu.Unlock()
+	if live {
+		n.publishTx(height, index, tx)
+	}
+}
+
+// publishes the transaction to transaction subscriptions
+func (n *fakeNode) publishTx(height int64, index uint32, tx comettypes.Tx) {
+	n.mu.Lock()
+	subs := append([]fakeSubscription(nil), n.subs...)
+	n.mu.Unlock()
+	for _, sub := range subs {
+		if !strings.Contains(sub.query, comettypes.EventQueryTx.String()) {
+			continue
+		}
+		_ = sub.conn.WriteRPCResponse(context.Background(), rpctypes.NewRPCSuccessResponse(sub.req.ID, &coretypes.ResultEvent{
+			Query: sub.query,
+			Data:  comettypes.EventDataTx{TxResult: abci.TxResult{Height: height, Index: index, Tx: tx}},
+		}))
+	}
+}
+
+// serves the transactions included in blocks within the `tx.height` bounds of the query, in order
+func (n *fakeNode) txSearch(_ *rpctypes.Context, query string, _ bool, _, _ *int, _ string) (*coretypes.ResultTxSearch, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	from, to := int64(1), n.height
+	for _, match := range txHeightBound.FindAllStringSubmatch(query, -1) {
+		bound, err := strconv.ParseInt(match[2], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		switch match[1] {
+		case ">":
+			from = bound + 1
+		case ">=":
+			from = bound
+		case "<":
+			to = bound - 1
+		case "<=":
+			to = bound
+		}
+	}
+	res := &coretypes.ResultTxSearch{}
+	for height := from; height <= to; height++ {
+		for index, tx := range n.blockTxs[height] {
+			res.Txs = append(res.Txs, &coretypes.ResultTx{
+				Hash:     tx.Hash(),
+				Height:   height,
+				Index:    uint32(index),
+				TxResult: n.txs[fmt.Sprintf("%X", tx.Hash())],
+				Tx:       tx,
+			})
+		}
+	}
+	res.TotalCount = len(res.Txs)
+	return res, nil
+}
+
+// matches the `tx.height` conditions of a transaction query
+var txHeightBound = regexp.MustCompile(`tx\.height\s*(>=|<=|>|<)\s*(\d+)`)
+
+func (n *fakeNode) unconfirmedTxs(_ *rpctypes.Context, _ *int) (*coretypes.ResultUnconfirmedTxs, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return &coretypes.ResultUnconfirmedTxs{Count: len(n.mempool), Total: len(n.mempool), Txs: n.mempool}, nil
+}
+
+func (n *fakeNode) numUnconfirmedTxs(*rpctypes.Context) (*coretypes.ResultUnconfirmedTxs, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	var size int64
+	for _, tx := range n.mempool {
+		size += int64(len(tx))
+	}
+	return &coretypes.ResultUnconfirmedTxs{Count: len(n.mempool), Total: len(n.mempool), TotalBytes: size}, nil
+}
+
+// includes broadcast transactions in the next block
+func (n *fakeNode) broadcastTxSync(_ *rpctypes.Context, tx comettypes.Tx) (*coretypes.ResultBroadcastTx, error) {
+	n.mu.Lock()
+	result := n.broadcastResults[len(n.broadcasts)]
+	n.broadcasts = append(n.broadcasts, tx)
+	n.mu.Unlock()
+	n.include(tx, result)
+	return &coretypes.ResultBroadcastTx{Hash: tx.Hash()}, nil
+}
+
+func (n *fakeNode) netInfo(*rpctypes.Context) (*coretypes.ResultNetInfo, error) {
+	return &coretypes.ResultNetInfo{NPeers: 3}, nil
+}
+
+func (n *fakeNode) block(_ *rpctypes.Context, height *int64) (*coretypes.ResultBlock, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	block := fakeBlock(*height)
+	block.Txs = n.blockTxs[*height]
+	return &coretypes.ResultBlock{Block: block}, nil
+}
+
+func (n *fakeNode) blockResults(_ *rpctypes.Context, height *int64) (*coretypes.ResultBlockResults, error) {
+	return &coretypes.ResultBlockResults{Height: *height}, nil
+}
+
+func fakeBlock(height int64) *comettypes.Block {
+	return &comettypes.Block{Header: comettypes.Header{ChainID: "test", Height: height}}
+}
+
+type nodeInfoService struct {
+	cmtservice.UnimplementedServiceServer
+	chainID string
+}
+
+func (s *nodeInfoService) GetNodeInfo(context.Context, *cmtservice.GetNodeInfoRequest) (*cmtservice.GetNodeInfoResponse, error) {
+	return &cmtservice.GetNodeInfoResponse{DefaultNodeInfo: &p2pproto.DefaultNodeInfo{Network: s.chainID}}, nil
+}
+
+// returns the address of a grpc server reporting the chain id, serving any additional services
+func newNodeInfoServer(t *testing.T, chainID string, register ...func(*grpc.Server)) string {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	// nodes encode responses with the gogoproto codec, as the default codec can't encode customtype fields
+	srv := grpc.NewServer(grpc.ForceServerCodec(codec.NewProtoCodec(codectypes.NewInterfaceRegistry()).GRPCCodec()))
+	cmtservice.RegisterServiceServer(srv, &nodeInfoService{chainID: chainID})
+	for _, fn := range register {
+		fn(srv)
+	}
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+	return lis.Addr().String()
+}
+
+func newTestConfig(rpcAddr, grpcAddr string) *compass.ClientConfig {
+	cfg := compass.GetSimdConfig()
+	cfg.RPCAddr = rpcAddr
+	cfg.GRPCAddr = grpcAddr
+	cfg.KeyringBackend = keyring.BackendMemory
+	return cfg
+}
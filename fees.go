@@ -0,0 +1,139 @@
+package compass
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"go.uber.org/zap"
+	"golang.org/x/exp/slices"
+
+	"github.com/teamscanworks/compass/types/osmosis/txfees"
+)
+
+// Returned when the signer doesn't hold enough of any fee denom to pay the fees of a transaction
+var ErrInsufficientFees = errors.New("insufficient funds for fees")
+
+// Selects the gas price of the denom fees are paid in, such that the signer holds enough of it to pay
+// for `gasLimit`. Denoms are tried in the order of the configured `GasPrices`, followed by any other
+// denom of `GasPrices` in alphabetical order. A zero gas price is returned when no gas prices are configured,
+// and `ErrInsufficientFees` when the signer can not afford any of the fee denoms
+func (c *Client) SelectGasPrice(ctx context.Context, gasLimit uint64) (sdk.DecCoin, error) {
+	return c.selectGasPrice(ctx, c.FromAddress(), gasLimit)
+}
+
+func (c *Client) selectGasPrice(ctx context.Context, signer string, gasLimit uint64) (sdk.DecCoin, error) {
+	// on osmosis balances are queried first, as only the fee tokens held by the signer are priced
+	var balances sdk.Coins
+	if c.osmosisFees() {
+		var err error
+		if balances, err = c.allBalances(ctx, signer); err != nil {
+			return sdk.DecCoin{}, fmt.Errorf("failed to query balances %v", err)
+		}
+	}
+	prices, err := c.gasPrices(ctx, balances)
+	if err != nil {
+		return sdk.DecCoin{}, err
+	}
+	candidates := c.preferredGasPrices(prices)
+	if len(candidates) == 0 {
+		return sdk.DecCoin{}, nil
+	}
+	if candidates[0].IsZero() {
+		return candidates[0], nil
+	}
+	if !c.osmosisFees() {
+		if balances, err = c.allBalances(ctx, signer); err != nil {
+			return sdk.DecCoin{}, fmt.Errorf("failed to query balances %v", err)
+		}
+	}
+	required := make([]string, 0, len(candidates))
+	for _, price := range candidates {
+		fee := gasFee(price, gasLimit)
+		if balances.AmountOf(fee.Denom).GTE(fee.Amount) {
+			return price, nil
+		}
+		required = append(required, fee.String())
+	}
+	return sdk.DecCoin{}, fmt.Errorf("%w %s requires one of %s for %d gas, but holds %s",
+		ErrInsufficientFees, signer, strings.Join(required, ", "), gasLimit, balances)
+}
+
+// returns the gas price as accepted by the transaction factory, where no gas price pays no fees
+func gasPriceString(price sdk.DecCoin) string {
+	if price.Denom == "" || price.IsZero() {
+		return ""
+	}
+	return price.String()
+}
+
+// orders the gas prices by the order of the configured gas prices, any zero price being preferred
+// as it requires no balance at all
+func (c *Client) preferredGasPrices(prices sdk.DecCoins) []sdk.DecCoin {
+	var order []string
+	for _, entry := range strings.Split(c.cfg.GasPrices, ",") {
+		if price, err := sdk.ParseDecCoin(strings.TrimSpace(entry)); err == nil {
+			order = append(order, price.Denom)
+		}
+	}
+	rank := func(price sdk.DecCoin) int {
+		if price.IsZero() {
+			return -1
+		}
+		if idx := slices.Index(order, price.Denom); idx >= 0 {
+			return idx
+		}
+		return len(order)
+	}
+	preferred := append([]sdk.DecCoin(nil), prices...)
+	slices.SortStableFunc(preferred, func(a, b sdk.DecCoin) bool { return rank(a) < rank(b) })
+	return preferred
+}
+
+// returns the fee paid for the gas limit at the gas price, rounding up as the sdk does
+func gasFee(price sdk.DecCoin, gasLimit uint64) sdk.Coin {
+	amount := price.Amount.Mul(sdkmath.LegacyNewDec(int64(gasLimit))).Ceil().RoundInt()
+	return sdk.NewCoin(price.Denom, amount)
+}
+
+// restricts the gas prices to the fee tokens accepted by the osmosis txfees module, deriving the price of
+// held fee tokens without a gas price from that of the base denom and their spot price. Fee tokens which
+// aren't held are skipped, sparing a spot price query for each of them. The gas prices are returned
+// unchanged if the fee tokens can not be queried
+func (c *Client) osmosisFeeTokenPrices(ctx context.Context, prices sdk.DecCoins, held sdk.Coins) sdk.DecCoins {
+	queryClient := txfees.NewQueryClient(c.grpcConn())
+	baseRes, err := queryClient.BaseDenom(ctx, &txfees.QueryBaseDenomRequest{})
+	if err != nil {
+		c.log.Warn("failed to query txfees base denom", zap.Error(err))
+		return prices
+	}
+	tokensRes, err := queryClient.FeeTokens(ctx, &txfees.QueryFeeTokensRequest{})
+	if err != nil {
+		c.log.Warn("failed to query txfees fee tokens", zap.Error(err))
+		return prices
+	}
+	basePrice := prices.AmountOf(baseRes.BaseDenom)
+	accepted := sdk.DecCoins{}
+	if !basePrice.IsZero() {
+		accepted = append(accepted, sdk.NewDecCoinFromDec(baseRes.BaseDenom, basePrice))
+	}
+	for _, token := range tokensRes.FeeTokens {
+		if price := prices.AmountOf(token.Denom); !price.IsZero() {
+			accepted = append(accepted, sdk.NewDecCoinFromDec(token.Denom, price))
+			continue
+		}
+		if basePrice.IsZero() || !held.AmountOf(token.Denom).IsPositive() {
+			continue
+		}
+		// the spot price is the amount of the base denom a unit of the fee token is worth
+		spotRes, err := queryClient.DenomSpotPrice(ctx, &txfees.QueryDenomSpotPriceRequest{Denom: token.Denom})
+		if err != nil || !spotRes.SpotPrice.IsPositive() {
+			continue
+		}
+		accepted = append(accepted, sdk.NewDecCoinFromDec(token.Denom, basePrice.Quo(spotRes.SpotPrice)))
+	}
+	return accepted.Sort()
+}
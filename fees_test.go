@@ -0,0 +1,100 @@
+package compass_test
+
+import (
+	"context"
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/require"
+	"github.com/teamscanworks/compass"
+	"github.com/teamscanworks/compass/types/osmosis/txfees"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// serves the same balances for every account
+type balanceService struct {
+	banktypes.UnimplementedQueryServer
+	balances sdk.Coins
+}
+
+func (s *balanceService) AllBalances(context.Context, *banktypes.QueryAllBalancesRequest) (*banktypes.QueryAllBalancesResponse, error) {
+	return &banktypes.QueryAllBalancesResponse{Balances: s.balances}, nil
+}
+
+// serves the fee tokens of osmosis, with every fee token worth half a unit of the base denom
+type txfeesService struct {
+	txfees.UnimplementedQueryServer
+	// denoms of the spot price queries served
+	spotPriced []string
+}
+
+func (s *txfeesService) BaseDenom(context.Context, *txfees.QueryBaseDenomRequest) (*txfees.QueryBaseDenomResponse, error) {
+	return &txfees.QueryBaseDenomResponse{BaseDenom: "uosmo"}, nil
+}
+
+func (s *txfeesService) FeeTokens(context.Context, *txfees.QueryFeeTokensRequest) (*txfees.QueryFeeTokensResponse, error) {
+	return &txfees.QueryFeeTokensResponse{FeeTokens: []txfees.FeeToken{{Denom: "uatom", PoolID: 1}, {Denom: "uion", PoolID: 2}}}, nil
+}
+
+func (s *txfeesService) DenomSpotPrice(_ context.Context, req *txfees.QueryDenomSpotPriceRequest) (*txfees.QueryDenomSpotPriceResponse, error) {
+	s.spotPriced = append(s.spotPriced, req.Denom)
+	return &txfees.QueryDenomSpotPriceResponse{SpotPrice: sdkmath.LegacyNewDecWithPrec(5, 1)}, nil
+}
+
+func TestSelectGasPrice(t *testing.T) {
+	logger, err := zap.NewDevelopment()
+	require.NoError(t, err)
+	ctx := context.Background()
+	balances := &balanceService{}
+	feeTokens := &txfeesService{}
+	node := newFakeNode(t, "testing")
+	cfg := newTestConfig(node.srv.URL, newNodeInfoServer(t, "testing", func(srv *grpc.Server) {
+		banktypes.RegisterQueryServer(srv, balances)
+		txfees.RegisterQueryServer(srv, feeTokens)
+	}))
+	cfg.GasPrices = "0.1uosmo,0.5stake,0.2uatom"
+	client, err := compass.NewClient(logger, cfg, []keyring.Option{compass.DefaultSignatureOptions()})
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close(context.Background()) })
+
+	// the first configured denom the signer can afford is selected
+	balances.balances = sdk.NewCoins(sdk.NewInt64Coin("uosmo", 999), sdk.NewInt64Coin("uatom", 2000))
+	price, err := client.SelectGasPrice(ctx, 10_000)
+	require.NoError(t, err)
+	require.Equal(t, "uatom", price.Denom)
+	balances.balances = sdk.NewCoins(sdk.NewInt64Coin("uosmo", 1000))
+	price, err = client.SelectGasPrice(ctx, 10_000)
+	require.NoError(t, err)
+	require.Equal(t, "uosmo", price.Denom)
+
+	balances.balances = sdk.NewCoins(sdk.NewInt64Coin("stake", 4999), sdk.NewInt64Coin("uion", 1_000_000))
+	_, err = client.SelectGasPrice(ctx, 10_000)
+	require.ErrorIs(t, err, compass.ErrInsufficientFees)
+	require.ErrorContains(t, err, "1000uosmo, 5000stake, 2000uatom")
+
+	// osmosis only accepts its fee tokens, priced against the base denom when not configured and held
+	// by the signer
+	cfg.ExtraCodecs = []string{"osmosis"}
+	_, err = client.AddKey("default", 118)
+	require.NoError(t, err)
+	require.NoError(t, client.SetFromAddress())
+	prices, err := client.GasPrices(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "0.200000000000000000uatom,0.200000000000000000uion,0.100000000000000000uosmo", prices.String())
+	require.Equal(t, []string{"uion"}, feeTokens.spotPriced)
+	balances.balances = sdk.NewCoins(sdk.NewInt64Coin("uatom", 2000))
+	prices, err = client.GasPrices(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "0.200000000000000000uatom,0.100000000000000000uosmo", prices.String())
+	require.Equal(t, []string{"uion"}, feeTokens.spotPriced)
+
+	balances.balances = sdk.NewCoins(sdk.NewInt64Coin("uion", 2000))
+	price, err = client.SelectGasPrice(ctx, 10_000)
+	require.NoError(t, err)
+	require.Equal(t, "uion", price.Denom)
+	require.Equal(t, []string{"uion", "uion"}, feeTokens.spotPriced)
+}
@@ -0,0 +1,313 @@
+package compass
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+
+	sdkmath "cosmossdk.io/math"
+	comettypes "github.com/cometbft/cometbft/types"
+	nodeservice "github.com/cosmos/cosmos-sdk/client/grpc/node"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"go.uber.org/zap"
+	"golang.org/x/exp/slices"
+)
+
+// sources of gas prices which may be used as the `GasPriceSource`
+const (
+	// the `GasPrices` of the configuration
+	GasPriceSourceStatic = "static"
+	// the `minimum-gas-prices` of the node, queried through the `node/config` gRPC service
+	GasPriceSourceNode = "node"
+	// the base fee of the x/feemarket module
+	GasPriceSourceFeeMarket = "feemarket"
+	// a percentile of the gas prices paid by transactions in recent blocks
+	GasPriceSourcePercentile = "percentile"
+)
+
+var gasPriceSources = []string{
+	GasPriceSourceStatic,
+	GasPriceSourceNode,
+	GasPriceSourceFeeMarket,
+	GasPriceSourcePercentile,
+}
+
+const (
+	// number of recent blocks sampled by the percentile source
+	DefaultGasPriceBlocks = 10
+	// percentile of the sampled gas prices used by the percentile source
+	DefaultGasPricePercentile = 50
+)
+
+const (
+	feeMarketGasPricesMethod   = "/feemarket.feemarket.v1.Query/GasPrices"
+	feeMarketGasPricesRequest  = "feemarket.feemarket.v1.GasPricesRequest"
+	feeMarketGasPricesResponse = "feemarket.feemarket.v1.GasPricesResponse"
+)
+
+// Provides the gas prices paid by transactions
+type GasPriceProvider interface {
+	// Returns the current gas price of every denom fees may be paid in
+	GasPrices(ctx context.Context) (sdk.DecCoins, error)
+}
+
+// Gas prices which never change
+type StaticGasPrices sdk.DecCoins
+
+func (p StaticGasPrices) GasPrices(context.Context) (sdk.DecCoins, error) {
+	return sdk.DecCoins(p), nil
+}
+
+// Gas prices of the `minimum-gas-prices` configured by the operator of the node the client is connected to
+type NodeGasPrices struct {
+	client *Client
+}
+
+// Returns a provider querying the node the client is connected to
+func NewNodeGasPrices(c *Client) *NodeGasPrices {
+	return &NodeGasPrices{client: c}
+}
+
+func (p *NodeGasPrices) GasPrices(ctx context.Context) (sdk.DecCoins, error) {
+	res, err := nodeservice.NewServiceClient(p.client.grpcConn()).Config(ctx, &nodeservice.ConfigRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query node config %s", err)
+	}
+	prices, err := sdk.ParseDecCoins(res.MinimumGasPrice)
+	if err != nil {
+		return nil, fmt.Errorf("invalid minimum gas prices %q %s", res.MinimumGasPrice, err)
+	}
+	return prices, nil
+}
+
+// Gas prices of the x/feemarket module, which adjusts the base fee of every block following EIP-1559. The
+// types of the module are resolved through the node, so it must support gRPC server reflection
+type FeeMarketGasPrices struct {
+	client *Client
+}
+
+// Returns a provider querying the x/feemarket module of the chain the client is connected to
+func NewFeeMarketGasPrices(c *Client) *FeeMarketGasPrices {
+	return &FeeMarketGasPrices{client: c}
+}
+
+func (p *FeeMarketGasPrices) GasPrices(ctx context.Context) (sdk.DecCoins, error) {
+	reqType, err := p.client.dynamicTypes().MessageType(ctx, feeMarketGasPricesRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve feemarket types %s", err)
+	}
+	resType, err := p.client.dynamicTypes().MessageType(ctx, feeMarketGasPricesResponse)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve feemarket types %s", err)
+	}
+	res := resType.New()
+	if err := p.client.grpcConn().Invoke(ctx, feeMarketGasPricesMethod, reqType.New().Interface(), res.Interface()); err != nil {
+		return nil, fmt.Errorf("failed to query feemarket gas prices %s", err)
+	}
+	fd := res.Descriptor().Fields().ByName("prices")
+	if fd == nil || !fd.IsList() {
+		return nil, fmt.Errorf("unexpected feemarket response %s", res.Descriptor().FullName())
+	}
+	list := res.Get(fd).List()
+	prices := make(sdk.DecCoins, 0, list.Len())
+	for i := 0; i < list.Len(); i++ {
+		coin := list.Get(i).Message()
+		denom, _ := findField(coin, "denom")
+		amount, _ := findField(coin, "amount")
+		// decimals are encoded as integers scaled by 10^18
+		var price sdkmath.LegacyDec
+		if err := price.Unmarshal([]byte(amount.String())); err != nil {
+			return nil, fmt.Errorf("invalid feemarket gas price %q %s", amount.String(), err)
+		}
+		prices = append(prices, sdk.NewDecCoinFromDec(denom.String(), price))
+	}
+	return prices.Sort(), nil
+}
+
+// Gas prices estimated from a percentile of the prices paid by transactions included in recent blocks,
+// recomputed once a new block is produced
+type PercentileGasPrices struct {
+	client     *Client
+	blocks     int
+	percentile float64
+
+	mu     sync.Mutex
+	height int64
+	prices sdk.DecCoins
+}
+
+// Returns a provider sampling the given number of recent blocks, with the percentile between 0 and 100
+func NewPercentileGasPrices(c *Client, blocks int, percentile float64) *PercentileGasPrices {
+	return &PercentileGasPrices{client: c, blocks: blocks, percentile: percentile}
+}
+
+func (p *PercentileGasPrices) GasPrices(ctx context.Context) (sdk.DecCoins, error) {
+	status, err := p.client.rpcClient().Status(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query status %s", err)
+	}
+	latest := status.SyncInfo.LatestBlockHeight
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.height == latest {
+		return p.prices, nil
+	}
+	samples := make(map[string][]sdkmath.LegacyDec)
+	for height := latest; height > 0 && height > latest-int64(p.blocks); height-- {
+		height := height
+		block, err := p.client.rpcClient().Block(ctx, &height)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query block %d %s", height, err)
+		}
+		for _, tx := range block.Block.Txs {
+			fee, gasLimit, err := txFee(tx)
+			if err != nil || gasLimit == 0 {
+				continue
+			}
+			for _, coin := range fee {
+				price := sdkmath.LegacyNewDecFromInt(coin.Amount).QuoInt64(int64(gasLimit))
+				samples[coin.Denom] = append(samples[coin.Denom], price)
+			}
+		}
+	}
+	prices := make(sdk.DecCoins, 0, len(samples))
+	for denom, sampled := range samples {
+		slices.SortFunc(sampled, func(a, b sdkmath.LegacyDec) bool { return a.LT(b) })
+		// nearest rank of the percentile
+		rank := int(math.Ceil(p.percentile/100*float64(len(sampled)))) - 1
+		if rank < 0 {
+			rank = 0
+		} else if rank >= len(sampled) {
+			rank = len(sampled) - 1
+		}
+		prices = append(prices, sdk.NewDecCoinFromDec(denom, sampled[rank]))
+	}
+	p.height, p.prices = latest, prices.Sort()
+	return p.prices, nil
+}
+
+// returns the fee and gas limit of the transaction, without decoding its messages
+func txFee(tx comettypes.Tx) (sdk.Coins, uint64, error) {
+	var raw txtypes.TxRaw
+	if err := raw.Unmarshal(tx); err != nil {
+		return nil, 0, err
+	}
+	var authInfo txtypes.AuthInfo
+	if err := authInfo.Unmarshal(raw.AuthInfoBytes); err != nil {
+		return nil, 0, err
+	}
+	if authInfo.Fee == nil {
+		return nil, 0, nil
+	}
+	return authInfo.Fee.Amount, authInfo.Fee.GasLimit, nil
+}
+
+// Gas prices of another provider bounded by minimum and maximum prices, by denom. When the provider returns
+// no prices the minimum prices are used
+type ClampedGasPrices struct {
+	Provider GasPriceProvider
+	Min      sdk.DecCoins
+	Max      sdk.DecCoins
+}
+
+func (p ClampedGasPrices) GasPrices(ctx context.Context) (sdk.DecCoins, error) {
+	prices, err := p.Provider.GasPrices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if prices.Empty() {
+		return p.Min, nil
+	}
+	clamped := make(sdk.DecCoins, 0, len(prices))
+	for _, price := range prices {
+		if floor := p.Min.AmountOf(price.Denom); price.Amount.LT(floor) {
+			price.Amount = floor
+		}
+		if ceiling := p.Max.AmountOf(price.Denom); !ceiling.IsZero() && price.Amount.GT(ceiling) {
+			price.Amount = ceiling
+		}
+		clamped = append(clamped, price)
+	}
+	return clamped, nil
+}
+
+// Sets the provider of gas prices, overriding the `GasPriceSource` of the configuration. The minimum
+// and maximum gas prices of the configuration still apply
+func (c *Client) SetGasPriceProvider(provider GasPriceProvider) {
+	c.gasPriceProvider = provider
+}
+
+// Returns the gas prices of every denom fees may be paid in, one of which is selected by `SelectGasPrice`
+// when building transactions. Falls back to the `GasPrices` of the configuration when the provider fails.
+// On osmosis, fee tokens without a configured gas price are only included if held by the signing key
+func (c *Client) GasPrices(ctx context.Context) (sdk.DecCoins, error) {
+	var held sdk.Coins
+	if c.osmosisFees() && c.FromAddress() != "" {
+		balances, err := c.allBalances(ctx, c.FromAddress())
+		if err != nil {
+			c.log.Warn("failed to query balances, only using configured fee tokens", zap.Error(err))
+		}
+		held = balances
+	}
+	return c.gasPrices(ctx, held)
+}
+
+// returns the gas prices, pricing any osmosis fee tokens of `held` which don't have a configured gas price
+func (c *Client) gasPrices(ctx context.Context, held sdk.Coins) (sdk.DecCoins, error) {
+	static, err := sdk.ParseDecCoins(c.cfg.GasPrices)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gas-prices %s", err)
+	}
+	minPrices, err := sdk.ParseDecCoins(c.cfg.MinGasPrices)
+	if err != nil {
+		return nil, fmt.Errorf("invalid min-gas-prices %s", err)
+	}
+	maxPrices, err := sdk.ParseDecCoins(c.cfg.MaxGasPrices)
+	if err != nil {
+		return nil, fmt.Errorf("invalid max-gas-prices %s", err)
+	}
+	provider := c.gasPriceProvider
+	if provider == nil {
+		provider = c.configuredGasPriceProvider(static)
+	}
+	prices, err := ClampedGasPrices{Provider: provider, Min: minPrices, Max: maxPrices}.GasPrices(ctx)
+	if err != nil {
+		c.log.Warn("failed to get gas prices, using the configured gas prices", zap.Error(err))
+		prices = static
+	}
+	if prices.Empty() {
+		prices = static
+	}
+	if c.osmosisFees() {
+		return c.osmosisFeeTokenPrices(ctx, prices, held), nil
+	}
+	return prices, nil
+}
+
+// returns true if fees are restricted to the fee tokens of the osmosis txfees module
+func (c *Client) osmosisFees() bool {
+	return slices.Contains(c.cfg.ExtraCodecs, "osmosis")
+}
+
+// returns the provider selected by the `GasPriceSource` of the configuration
+func (c *Client) configuredGasPriceProvider(static sdk.DecCoins) GasPriceProvider {
+	switch c.cfg.GasPriceSource {
+	case GasPriceSourceNode:
+		return NewNodeGasPrices(c)
+	case GasPriceSourceFeeMarket:
+		return NewFeeMarketGasPrices(c)
+	case GasPriceSourcePercentile:
+		c.percentileOnce.Do(func() {
+			percentile := c.cfg.GasPricePercentile
+			if percentile == 0 {
+				percentile = DefaultGasPricePercentile
+			}
+			c.percentileGasPrices = NewPercentileGasPrices(c, DefaultGasPriceBlocks, percentile)
+		})
+		return c.percentileGasPrices
+	default:
+		return StaticGasPrices(static)
+	}
+}
@@ -0,0 +1,104 @@
+package compass_test
+
+import (
+	"context"
+	"testing"
+
+	comettypes "github.com/cometbft/cometbft/types"
+	nodeservice "github.com/cosmos/cosmos-sdk/client/grpc/node"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/stretchr/testify/require"
+	"github.com/teamscanworks/compass"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// serves the operator configuration of a node
+type nodeConfigService struct {
+	nodeservice.UnimplementedServiceServer
+	minGasPrices string
+}
+
+func (s *nodeConfigService) Config(context.Context, *nodeservice.ConfigRequest) (*nodeservice.ConfigResponse, error) {
+	return &nodeservice.ConfigResponse{MinimumGasPrice: s.minGasPrices}, nil
+}
+
+// returns a transaction paying the fee for the gas limit
+func feeTx(t *testing.T, fee string, gasLimit uint64) comettypes.Tx {
+	amount, err := sdk.ParseCoinsNormalized(fee)
+	require.NoError(t, err)
+	authInfo, err := (&txtypes.AuthInfo{Fee: &txtypes.Fee{Amount: amount, GasLimit: gasLimit}}).Marshal()
+	require.NoError(t, err)
+	tx, err := (&txtypes.TxRaw{AuthInfoBytes: authInfo}).Marshal()
+	require.NoError(t, err)
+	return tx
+}
+
+func TestGasPrices(t *testing.T) {
+	logger, err := zap.NewDevelopment()
+	require.NoError(t, err)
+	ctx := context.Background()
+	node := newFakeNode(t, "testing")
+	cfg := newTestConfig(node.srv.URL, newNodeInfoServer(t, "testing", func(srv *grpc.Server) {
+		nodeservice.RegisterServiceServer(srv, &nodeConfigService{minGasPrices: "0.5uatom,0.01stake"})
+	}))
+	cfg.GasPriceSource = compass.GasPriceSourceNode
+	require.NoError(t, cfg.Validate())
+	client, err := compass.NewClient(logger, cfg, []keyring.Option{compass.DefaultSignatureOptions()})
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close(context.Background()) })
+
+	prices, err := client.GasPrices(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "0.010000000000000000stake,0.500000000000000000uatom", prices.String())
+
+	// clamps bound the prices of the provider
+	cfg.MinGasPrices = "0.02stake"
+	prices, err = client.GasPrices(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "0.020000000000000000stake,0.500000000000000000uatom", prices.String())
+	cfg.MinGasPrices, cfg.MaxGasPrices = "", "0.001stake"
+	prices, err = client.GasPrices(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "0.001000000000000000stake,0.500000000000000000uatom", prices.String())
+	cfg.MaxGasPrices = ""
+
+	// the configured gas prices are used when the provider fails
+	client.SetGasPriceProvider(compass.NewFeeMarketGasPrices(client))
+	prices, err = client.GasPrices(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "1.000000000000000000stake", prices.String())
+
+	node.mu.Lock()
+	node.height = 3
+	node.mu.Unlock()
+	node.blockTxs[1] = []comettypes.Tx{feeTx(t, "100stake", 100)}
+	node.blockTxs[2] = []comettypes.Tx{feeTx(t, "200stake", 100), feeTx(t, "10uatom", 100)}
+	node.blockTxs[3] = []comettypes.Tx{feeTx(t, "300stake", 100), feeTx(t, "400stake", 100), comettypes.Tx("invalid")}
+	percentile := compass.NewPercentileGasPrices(client, 2, 50)
+	prices, err = percentile.GasPrices(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "3.000000000000000000stake,0.100000000000000000uatom", prices.String())
+	highest, err := compass.NewPercentileGasPrices(client, 3, 100).GasPrices(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "4.000000000000000000stake,0.100000000000000000uatom", highest.String())
+
+	// estimates are cached until a new block is produced
+	node.blockTxs[3] = nil
+	cached, err := percentile.GasPrices(ctx)
+	require.NoError(t, err)
+	require.Equal(t, prices, cached)
+}
+
+func TestValidateGasPrices(t *testing.T) {
+	cfg := compass.GetSimdConfig()
+	cfg.GasPriceSource = "oracle"
+	cfg.GasPricePercentile = 101
+	cfg.MinGasPrices = "stake"
+	err := cfg.Validate()
+	require.ErrorContains(t, err, "unsupported gas-price-source")
+	require.ErrorContains(t, err, "gas-price-percentile")
+	require.ErrorContains(t, err, "invalid min-gas-prices")
+}
@@ -0,0 +1,92 @@
+package compass
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// interval at which the node is polled while waiting on it
+const healthPollInterval = 500 * time.Millisecond
+
+// The status of the node the client is connected to
+type NodeHealth struct {
+	ChainID string
+	// version of cometbft run by the node
+	NodeVersion string
+	// version of the application run by the node
+	AppVersion      string
+	LatestHeight    int64
+	LatestBlockTime time.Time
+	CatchingUp      bool
+	Peers           int
+	// time elapsed between the latest block and now, which grows when the node stalls or falls behind
+	Lag time.Duration
+}
+
+// Returns the status of the node, which is usable once it is no longer catching up and its lag is
+// within a few block times
+func (c *Client) NodeHealth(ctx context.Context) (*NodeHealth, error) {
+	status, err := c.rpcClient().Status(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query node status %s", err)
+	}
+	abciInfo, err := c.rpcClient().ABCIInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query abci info %s", err)
+	}
+	netInfo, err := c.rpcClient().NetInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query net info %s", err)
+	}
+	return &NodeHealth{
+		ChainID:         status.NodeInfo.Network,
+		NodeVersion:     status.NodeInfo.Version,
+		AppVersion:      abciInfo.Response.Version,
+		LatestHeight:    status.SyncInfo.LatestBlockHeight,
+		LatestBlockTime: status.SyncInfo.LatestBlockTime,
+		CatchingUp:      status.SyncInfo.CatchingUp,
+		Peers:           netInfo.NPeers,
+		Lag:             time.Since(status.SyncInfo.LatestBlockTime),
+	}, nil
+}
+
+// Waits until the node has committed a block at the given height. Errors querying the node are retried,
+// such that it may be used while the node is starting
+func (c *Client) WaitForHeight(ctx context.Context, height int64) error {
+	return c.waitForStatus(ctx, fmt.Sprintf("height %d", height), func(latest int64, _ bool) bool {
+		return latest >= height
+	})
+}
+
+// Waits until the node has caught up with the chain. Errors querying the node are retried, such that it
+// may be used while the node is starting
+func (c *Client) WaitForSync(ctx context.Context) error {
+	return c.waitForStatus(ctx, "sync", func(latest int64, catchingUp bool) bool {
+		return latest > 0 && !catchingUp
+	})
+}
+
+// polls the status of the node until the condition is met or the context is done
+func (c *Client) waitForStatus(ctx context.Context, desc string, done func(latest int64, catchingUp bool) bool) error {
+	var lastErr error
+	for {
+		status, err := c.rpcClient().Status(ctx)
+		if err == nil && done(status.SyncInfo.LatestBlockHeight, status.SyncInfo.CatchingUp) {
+			return nil
+		}
+		if errors.Is(err, ErrClosed) {
+			return err
+		}
+		if err != nil {
+			lastErr = err
+		}
+		if err := sleepContext(ctx, healthPollInterval); err != nil {
+			if lastErr != nil {
+				return fmt.Errorf("failed waiting for %s %w (last error %v)", desc, err, lastErr)
+			}
+			return fmt.Errorf("failed waiting for %s %w", desc, err)
+		}
+	}
+}
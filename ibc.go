@@ -0,0 +1,332 @@
+package compass
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"go.uber.org/zap"
+
+	"github.com/teamscanworks/compass/types/ibc/channel"
+	ibcclient "github.com/teamscanworks/compass/types/ibc/client"
+	"github.com/teamscanworks/compass/types/ibc/transfer"
+)
+
+const (
+	// number of blocks after which transfers time out on the counterparty chain
+	DefaultTransferTimeoutBlocks = 1000
+	// duration after which transfers time out on the counterparty chain
+	DefaultTransferTimeout = 10 * time.Minute
+
+	// interval at which chains are queried for packet lifecycle events
+	packetPollInterval = 2 * time.Second
+)
+
+// The lifecycle stage of an ibc packet
+type PacketStatus string
+
+const (
+	// the packet was sent, but has not been received by the destination chain
+	PacketSent PacketStatus = "sent"
+	// the packet was received by the destination chain, but the acknowledgement has not been relayed back
+	PacketReceived PacketStatus = "received"
+	// the acknowledgement of the packet was relayed back to the source chain
+	PacketAcknowledged PacketStatus = "acknowledged"
+	// the packet timed out, and the timeout was relayed back to the source chain
+	PacketTimedOut PacketStatus = "timed-out"
+)
+
+// An ibc packet, and the transaction which sent it
+type SentPacket struct {
+	channel.Packet
+	TxHash string
+	Height int64
+}
+
+// The lifecycle of a packet as observed on the source and destination chains
+type PacketResult struct {
+	Packet channel.Packet
+	Status PacketStatus
+	// hash of the transaction receiving the packet on the destination chain
+	RecvTxHash string
+	// acknowledgement written by the destination chain, which is nil until the packet is received
+	Acknowledgement *channel.Acknowledgement
+	// hash of the transaction acknowledging the packet on the source chain
+	AckTxHash string
+	// hash of the transaction timing out the packet on the source chain
+	TimeoutTxHash string
+}
+
+// Configures ics-20 transfers sent with `SendIBCTransfer`
+type TransferOption func(*transferOptions)
+
+type transferOptions struct {
+	timeoutBlocks uint64
+	timeout       time.Duration
+	memo          string
+}
+
+// Sets the number of counterparty blocks after which the transfer times out, with 0 disabling the timeout height
+func WithTransferTimeoutBlocks(blocks uint64) TransferOption {
+	return func(opts *transferOptions) {
+		opts.timeoutBlocks = blocks
+	}
+}
+
+// Sets the duration, relative to the latest counterparty block time, after which the transfer times out,
+// with 0 disabling the timeout timestamp
+func WithTransferTimeout(timeout time.Duration) TransferOption {
+	return func(opts *transferOptions) {
+		opts.timeout = timeout
+	}
+}
+
+// Sets the memo included in the transfer packet
+func WithTransferMemo(memo string) TransferOption {
+	return func(opts *transferOptions) {
+		opts.memo = memo
+	}
+}
+
+// Returns the timeout height and timestamp for packets received by the chain this client is connected to,
+// such that the packet times out once `blocks` blocks have been produced or `timeout` has elapsed. The
+// revision number of the height is derived from the chain id reported by the node. A value of 0 for either
+// `blocks` or `timeout` disables the respective timeout.
+func (c *Client) PacketTimeout(ctx context.Context, blocks uint64, timeout time.Duration) (ibcclient.Height, uint64, error) {
+	status, err := c.rpcClient().Status(ctx)
+	if err != nil {
+		return ibcclient.Height{}, 0, fmt.Errorf("failed to query node status %s", err)
+	}
+	var (
+		height    ibcclient.Height
+		timestamp uint64
+	)
+	if blocks > 0 {
+		height = ibcclient.NewHeight(
+			ibcclient.ParseChainID(status.NodeInfo.Network),
+			uint64(status.SyncInfo.LatestBlockHeight)+blocks,
+		)
+	}
+	if timeout > 0 {
+		timestamp = uint64(status.SyncInfo.LatestBlockTime.Add(timeout).UnixNano())
+	}
+	return height, timestamp, nil
+}
+
+// Sends an ics-20 transfer of the token over the given channel to the receiver on the counterparty chain,
+// returning the packet which was sent. The timeout is computed from the latest block of the counterparty,
+// defaulting to `DefaultTransferTimeoutBlocks` blocks and `DefaultTransferTimeout`.
+//
+// The returned packet can be followed through its lifecycle with `TrackPacket`
+func (c *Client) SendIBCTransfer(
+	ctx context.Context,
+	counterparty *Client,
+	sourceChannel string,
+	token sdk.Coin,
+	receiver string,
+	opts ...TransferOption,
+) (*SentPacket, error) {
+	options := &transferOptions{
+		timeoutBlocks: DefaultTransferTimeoutBlocks,
+		timeout:       DefaultTransferTimeout,
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+	if options.timeoutBlocks == 0 && options.timeout == 0 {
+		return nil, fmt.Errorf("either a timeout height or timestamp is required")
+	}
+	timeoutHeight, timeoutTimestamp, err := counterparty.PacketTimeout(ctx, options.timeoutBlocks, options.timeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute counterparty timeout %s", err)
+	}
+	msg := transfer.NewMsgTransfer(
+		transfer.PortID, sourceChannel,
+		token, c.FromAddress(), receiver,
+		timeoutHeight, timeoutTimestamp,
+		options.memo,
+	)
+	txHash, err := c.SendTransaction(ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+	packets, err := c.SentPackets(ctx, txHash)
+	if err != nil {
+		return nil, err
+	}
+	if len(packets) == 0 {
+		return nil, fmt.Errorf("transaction %s did not send a packet", txHash)
+	}
+	return &packets[0], nil
+}
+
+// Returns the packets sent by the transaction with the given hash, failing if the transaction
+// was not successfully executed
+func (c *Client) SentPackets(ctx context.Context, txHash string) ([]SentPacket, error) {
+	res, err := c.txResult(ctx, txHash)
+	if err != nil {
+		return nil, err
+	}
+	var packets []SentPacket
+	for _, event := range res.TxResult.Events {
+		if event.Type != "send_packet" {
+			continue
+		}
+		packet, err := ParsePacketEvent(event)
+		if err != nil {
+			return nil, err
+		}
+		packets = append(packets, SentPacket{Packet: *packet, TxHash: txHash, Height: res.Height})
+	}
+	return packets, nil
+}
+
+// Follows the packet sent from the source chain until it is either acknowledged or timed out, returning its final
+// status. Both chains are polled for packet events, which requires their nodes to index transactions.
+//
+// If the context is cancelled before the packet lifecycle completes, the progress observed so far is returned
+// alongside the context error
+func TrackPacket(ctx context.Context, source, destination *Client, packet channel.Packet) (*PacketResult, error) {
+	result := &PacketResult{Packet: packet, Status: PacketSent}
+	ticker := time.NewTicker(packetPollInterval)
+	defer ticker.Stop()
+	for {
+		done, err := result.poll(ctx, source, destination)
+		if err != nil {
+			return result, err
+		}
+		if done {
+			return result, nil
+		}
+		select {
+		case <-ctx.Done():
+			return result, fmt.Errorf("packet %d is %s %w", packet.Sequence, result.Status, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// queries both chains for the next stages of the packet lifecycle, returning true once the packet
+// has been acknowledged or timed out
+func (r *PacketResult) poll(ctx context.Context, source, destination *Client) (bool, error) {
+	packet := r.Packet
+	if r.RecvTxHash == "" {
+		res, err := searchPacketEvent(ctx, destination, "recv_packet", "packet_dst", packet.DestinationPort, packet.DestinationChannel, packet.Sequence)
+		if err != nil {
+			return false, err
+		}
+		if res != nil {
+			r.Status = PacketReceived
+			r.RecvTxHash = res.Hash.String()
+			ack, err := packetAcknowledgement(res, packet)
+			if err != nil {
+				return false, err
+			}
+			r.Acknowledgement = ack
+			source.log.Debug("packet received", zap.Uint64("packet.sequence", packet.Sequence), zap.String("tx.hash", r.RecvTxHash))
+		}
+	}
+	res, err := searchPacketEvent(ctx, source, "acknowledge_packet", "packet_src", packet.SourcePort, packet.SourceChannel, packet.Sequence)
+	if err != nil {
+		return false, err
+	}
+	if res != nil {
+		r.Status = PacketAcknowledged
+		r.AckTxHash = res.Hash.String()
+		return true, nil
+	}
+	res, err = searchPacketEvent(ctx, source, "timeout_packet", "packet_src", packet.SourcePort, packet.SourceChannel, packet.Sequence)
+	if err != nil {
+		return false, err
+	}
+	if res != nil {
+		r.Status = PacketTimedOut
+		r.TimeoutTxHash = res.Hash.String()
+		return true, nil
+	}
+	return false, nil
+}
+
+// Parses the packet described by the attributes of a packet event (ie: `send_packet`, `recv_packet`)
+func ParsePacketEvent(event abci.Event) (*channel.Packet, error) {
+	attrs := make(map[string]string, len(event.Attributes))
+	for _, attr := range event.Attributes {
+		attrs[attr.Key] = attr.Value
+	}
+	packet := &channel.Packet{
+		SourcePort:         attrs["packet_src_port"],
+		SourceChannel:      attrs["packet_src_channel"],
+		DestinationPort:    attrs["packet_dst_port"],
+		DestinationChannel: attrs["packet_dst_channel"],
+	}
+	var err error
+	if packet.Sequence, err = strconv.ParseUint(attrs["packet_sequence"], 10, 64); err != nil {
+		return nil, fmt.Errorf("invalid packet sequence %s", err)
+	}
+	if packet.TimeoutHeight, err = ibcclient.ParseHeight(attrs["packet_timeout_height"]); err != nil {
+		return nil, fmt.Errorf("invalid packet timeout height %s", err)
+	}
+	if packet.TimeoutTimestamp, err = strconv.ParseUint(attrs["packet_timeout_timestamp"], 10, 64); err != nil {
+		return nil, fmt.Errorf("invalid packet timeout timestamp %s", err)
+	}
+	if dataHex, ok := attrs["packet_data_hex"]; ok {
+		if packet.Data, err = hex.DecodeString(dataHex); err != nil {
+			return nil, fmt.Errorf("invalid packet data %s", err)
+		}
+	} else {
+		packet.Data = []byte(attrs["packet_data"])
+	}
+	return packet, nil
+}
+
+// returns the first transaction emitting an event of the given type for the packet, or nil if there is no such transaction
+func searchPacketEvent(ctx context.Context, c *Client, eventType, attrPrefix, port, channelID string, sequence uint64) (*coretypes.ResultTx, error) {
+	query := fmt.Sprintf(
+		"%s.%s_port='%s' AND %s.%s_channel='%s' AND %s.packet_sequence='%d'",
+		eventType, attrPrefix, port,
+		eventType, attrPrefix, channelID,
+		eventType, sequence,
+	)
+	page, perPage := 1, 1
+	res, err := c.rpcClient().TxSearch(ctx, query, false, &page, &perPage, "asc")
+	if err != nil {
+		return nil, fmt.Errorf("failed to search for %s events %s", eventType, err)
+	}
+	if len(res.Txs) == 0 {
+		return nil, nil
+	}
+	return res.Txs[0], nil
+}
+
+// returns the acknowledgement written for the packet by the receiving transaction, or nil if the acknowledgement
+// is written asynchronously
+func packetAcknowledgement(res *coretypes.ResultTx, packet channel.Packet) (*channel.Acknowledgement, error) {
+	for _, event := range res.TxResult.Events {
+		if event.Type != "write_acknowledgement" {
+			continue
+		}
+		attrs := make(map[string]string, len(event.Attributes))
+		for _, attr := range event.Attributes {
+			attrs[attr.Key] = attr.Value
+		}
+		if attrs["packet_sequence"] != strconv.FormatUint(packet.Sequence, 10) ||
+			attrs["packet_dst_channel"] != packet.DestinationChannel ||
+			attrs["packet_dst_port"] != packet.DestinationPort {
+			continue
+		}
+		ack := []byte(attrs["packet_ack"])
+		if ackHex, ok := attrs["packet_ack_hex"]; ok {
+			var err error
+			if ack, err = hex.DecodeString(ackHex); err != nil {
+				return nil, fmt.Errorf("invalid packet acknowledgement %s", err)
+			}
+		}
+		return channel.ParseAcknowledgement(ack)
+	}
+	return nil, nil
+}
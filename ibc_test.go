@@ -0,0 +1,117 @@
+package compass_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"github.com/teamscanworks/compass"
+	"github.com/teamscanworks/compass/types/ibc"
+	"github.com/teamscanworks/compass/types/ibc/channel"
+	ibcclient "github.com/teamscanworks/compass/types/ibc/client"
+	"github.com/teamscanworks/compass/types/ibc/transfer"
+)
+
+func TestMakeCodecIBC(t *testing.T) {
+	cdc := compass.MakeCodec(compass.ModuleBasics, nil)
+	for _, msg := range []sdk.Msg{
+		&transfer.MsgTransfer{},
+		&channel.MsgRecvPacket{},
+		&channel.MsgAcknowledgement{},
+		&channel.MsgTimeout{},
+		&ibcclient.MsgUpdateClient{},
+	} {
+		resolved, err := cdc.InterfaceRegistry.Resolve(sdk.MsgTypeURL(msg))
+		require.NoError(t, err)
+		require.IsType(t, msg, resolved)
+	}
+
+	sender := sdk.AccAddress([]byte("sender_address______"))
+	msg := transfer.NewMsgTransfer(
+		transfer.PortID, "channel-0",
+		sdk.NewInt64Coin("uatom", 100),
+		sdk.MustBech32ifyAddressBytes("cosmos", sender), "osmo1receiver",
+		ibcclient.NewHeight(1, 1000), 0,
+		"memo",
+	)
+	signers, _, err := newSigningCodec(t, ibc.AppModuleBasic{}.RegisterInterfaces).GetMsgV1Signers(msg)
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{sender}, signers)
+
+	packed, err := codectypes.NewAnyWithValue(msg)
+	require.NoError(t, err)
+	var unpacked sdk.Msg
+	require.NoError(t, cdc.InterfaceRegistry.UnpackAny(packed, &unpacked))
+	require.Equal(t, msg, unpacked)
+}
+
+func TestHeight(t *testing.T) {
+	require.Equal(t, uint64(4), ibcclient.ParseChainID("cosmoshub-4"))
+	require.Equal(t, uint64(1), ibcclient.ParseChainID("osmosis-1"))
+	require.Equal(t, uint64(0), ibcclient.ParseChainID("testing"))
+	require.Equal(t, uint64(0), ibcclient.ParseChainID("chain-0"))
+
+	height, err := ibcclient.ParseHeight("4-1200")
+	require.NoError(t, err)
+	require.Equal(t, ibcclient.NewHeight(4, 1200), height)
+	require.Equal(t, "4-1200", height.String())
+	require.True(t, height.GTE(ibcclient.NewHeight(3, 5000)))
+	require.False(t, height.GTE(ibcclient.NewHeight(4, 1201)))
+	_, err = ibcclient.ParseHeight("1200")
+	require.Error(t, err)
+}
+
+func TestParsePacketEvent(t *testing.T) {
+	data := []byte(`{"amount":"100","denom":"uatom","receiver":"osmo1receiver","sender":"cosmos1sender"}`)
+	event := abci.Event{
+		Type: "send_packet",
+		Attributes: []abci.EventAttribute{
+			{Key: "packet_data", Value: string(data)},
+			{Key: "packet_data_hex", Value: hex.EncodeToString(data)},
+			{Key: "packet_timeout_height", Value: "1-1000"},
+			{Key: "packet_timeout_timestamp", Value: "1690000000000000000"},
+			{Key: "packet_sequence", Value: "7"},
+			{Key: "packet_src_port", Value: "transfer"},
+			{Key: "packet_src_channel", Value: "channel-0"},
+			{Key: "packet_dst_port", Value: "transfer"},
+			{Key: "packet_dst_channel", Value: "channel-141"},
+		},
+	}
+	packet, err := compass.ParsePacketEvent(event)
+	require.NoError(t, err)
+	require.Equal(t, &channel.Packet{
+		Sequence:           7,
+		SourcePort:         "transfer",
+		SourceChannel:      "channel-0",
+		DestinationPort:    "transfer",
+		DestinationChannel: "channel-141",
+		Data:               data,
+		TimeoutHeight:      ibcclient.NewHeight(1, 1000),
+		TimeoutTimestamp:   1690000000000000000,
+	}, packet)
+
+	event.Attributes = event.Attributes[2:]
+	_, err = compass.ParsePacketEvent(event)
+	require.NoError(t, err)
+	event.Attributes = event.Attributes[1:]
+	_, err = compass.ParsePacketEvent(event)
+	require.Error(t, err)
+}
+
+func TestParseAcknowledgement(t *testing.T) {
+	ack, err := channel.ParseAcknowledgement([]byte(`{"result":"AQ=="}`))
+	require.NoError(t, err)
+	require.True(t, ack.Success())
+	require.Equal(t, []byte{1}, ack.GetResult())
+
+	ack, err = channel.ParseAcknowledgement([]byte(`{"error":"ABCI code: 1: error handling packet: see events for details"}`))
+	require.NoError(t, err)
+	require.False(t, ack.Success())
+	require.Contains(t, ack.GetError(), "error handling packet")
+
+	_, err = channel.ParseAcknowledgement([]byte(`{}`))
+	require.Error(t, err)
+}
@@ -0,0 +1,88 @@
+package compass
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// Configures transactions sent with `SendTransaction`
+type SendOption func(*sendOptions)
+
+type sendOptions struct {
+	idempotencyKey string
+}
+
+// Makes the send idempotent: the hash of the transaction is remembered under the key, and a later send with
+// the same key returns that hash instead of signing a new transaction if the original was included in a block,
+// is still in the mempool, or can be rebroadcast at its sequence. Keys are remembered in memory, or in the
+// journal when enabled such that they survive restarts
+func WithIdempotencyKey(key string) SendOption {
+	return func(opts *sendOptions) {
+		opts.idempotencyKey = key
+	}
+}
+
+// remembers the hash of the transaction sent with the idempotency key, journaled entries
+// already record their key so only clients without a journal keep them in memory
+func (c *Client) rememberIdempotentTx(key, hash string) {
+	if key == "" || c.Journal != nil {
+		return
+	}
+	if c.idempotentTxs == nil {
+		c.idempotentTxs = make(map[string]string)
+	}
+	c.idempotentTxs[key] = hash
+}
+
+// returns the hash of the transaction previously sent with the idempotency key if it was included,
+// is in the mempool, or could be rebroadcast, in which case it is confirmed before returning. An empty
+// hash is returned when a new transaction needs to be signed.
+//
+// Callers must hold `txLock`, and have prepared the sequence
+func (c *Client) resumeIdempotentTx(ctx context.Context, key string) (string, error) {
+	hash := c.idempotentTxs[key]
+	var entry *JournalEntry
+	if c.Journal != nil {
+		var err error
+		if entry, err = c.Journal.GetByIdempotencyKey(key); err != nil {
+			return "", fmt.Errorf("failed to read journal %s", err)
+		}
+		if entry != nil {
+			hash = entry.Hash
+		}
+	}
+	if hash == "" {
+		return "", nil
+	}
+	log := c.log.With(zap.String("idempotency.key", key), zap.String("tx.hash", hash))
+	if c.journalInclusion(ctx, hash) {
+		log.Info("transaction was already included")
+		return hash, nil
+	}
+	pending, err := c.inMempool(ctx, hash)
+	if err != nil {
+		return "", err
+	}
+	if pending {
+		log.Info("transaction is in the mempool, awaiting its inclusion")
+		return hash, c.confirmTx(ctx, hash, nil)
+	}
+	if entry == nil || !entry.Status.Pending() {
+		return "", nil
+	}
+	if entry.Sequence < c.seqNum {
+		// the sequence was used by another transaction, so the original can never be included
+		c.journalTransition(hash, TxStatusDropped, fmt.Sprintf("signer sequence is %d", c.seqNum), nil)
+		return "", nil
+	}
+	if entry.Sequence > c.seqNum {
+		return "", fmt.Errorf("%w %s at sequence %d awaits sequence %d", ErrPendingTx, hash, entry.Sequence, c.seqNum)
+	}
+	log.Info("rebroadcasting transaction")
+	if _, err := c.submitTx(ctx, hash, entry.TxBytes); err != nil {
+		return "", err
+	}
+	return hash, c.confirmTx(ctx, hash, nil)
+}
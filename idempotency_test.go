@@ -0,0 +1,57 @@
+package compass_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	comettypes "github.com/cometbft/cometbft/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/require"
+	"github.com/teamscanworks/compass"
+)
+
+func TestIdempotentSend(t *testing.T) {
+	client, node := newJournaledClient(t)
+	ctx := context.Background()
+	msg := &banktypes.MsgSend{}
+	journal := func(key string, tx comettypes.Tx, sequence uint64) string {
+		hash := fmt.Sprintf("%X", tx.Hash())
+		require.NoError(t, client.Journal.Record(&compass.JournalEntry{
+			Hash:           hash,
+			ChainID:        "testing",
+			Signer:         client.FromAddress(),
+			Sequence:       sequence,
+			IdempotencyKey: key,
+			TxBytes:        tx,
+		}))
+		return hash
+	}
+
+	// a transaction which was included is returned without signing a new one
+	included := comettypes.Tx("included")
+	hash := journal("included", included, 4)
+	node.include(included, abci.ExecTxResult{})
+	txHash, err := client.SendTransaction(ctx, msg, compass.WithIdempotencyKey("included"))
+	require.NoError(t, err)
+	require.Equal(t, hash, txHash)
+	entry, err := client.Journal.GetByIdempotencyKey("included")
+	require.NoError(t, err)
+	require.Equal(t, compass.TxStatusCommitted, entry.Status)
+
+	// a transaction in the mempool is awaited
+	pending := comettypes.Tx("pending")
+	hash = journal("pending", pending, 5)
+	node.mempool = append(node.mempool, pending)
+	time.AfterFunc(500*time.Millisecond, func() { node.include(pending, abci.ExecTxResult{}) })
+	txHash, err = client.SendTransaction(ctx, msg, compass.WithIdempotencyKey("pending"))
+	require.NoError(t, err)
+	require.Equal(t, hash, txHash)
+
+	// a transaction awaiting an earlier sequence can't be resumed
+	journal("future", comettypes.Tx("future"), 6)
+	_, err = client.SendTransaction(ctx, msg, compass.WithIdempotencyKey("future"))
+	require.ErrorIs(t, err, compass.ErrPendingTx)
+}
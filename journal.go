@@ -0,0 +1,466 @@
+package compass
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	comettypes "github.com/cometbft/cometbft/types"
+	bolt "go.etcd.io/bbolt"
+	"go.uber.org/zap"
+	"golang.org/x/exp/slices"
+)
+
+// name of the journal file created in `KeyDirectory` when no `JournalPath` is configured
+const DefaultJournalFile = "journal.db"
+
+var (
+	// journal entries keyed by transaction hash
+	journalTxsBucket = []byte("txs")
+	// transaction hashes keyed by signer and sequence
+	journalSequenceBucket = []byte("sequences")
+	// transaction hashes keyed by the idempotency key they were sent with
+	journalIdempotencyBucket = []byte("idempotency")
+)
+
+// Returned when sending a transaction while a previously sent transaction of the signer has not been
+// resolved, and might still be included at the same sequence
+var ErrPendingTx = errors.New("pending transaction")
+
+// The status of a journaled transaction
+type TxStatus string
+
+const (
+	// signed but not yet accepted by a node
+	TxStatusSigned TxStatus = "signed"
+	// accepted by a node, and awaiting inclusion in a block
+	TxStatusBroadcast TxStatus = "broadcast"
+	// included in a block and executed successfully
+	TxStatusCommitted TxStatus = "committed"
+	// included in a block but its execution failed
+	TxStatusFailed TxStatus = "failed"
+	// rejected by the node before inclusion
+	TxStatusRejected TxStatus = "rejected"
+	// never included, and its sequence has since been used by another transaction
+	TxStatusDropped TxStatus = "dropped"
+)
+
+// Returns true if the transaction may still be included in a block
+func (s TxStatus) Pending() bool {
+	return s == TxStatusSigned || s == TxStatusBroadcast
+}
+
+// A status transition of a journaled transaction
+type TxTransition struct {
+	Status TxStatus  `json:"status"`
+	At     time.Time `json:"at"`
+	// the reason of the transition, ie: the log of a failed transaction
+	Reason string `json:"reason,omitempty"`
+}
+
+// A transaction recorded by the journal
+type JournalEntry struct {
+	Hash     string `json:"hash"`
+	ChainID  string `json:"chain_id"`
+	Signer   string `json:"signer"`
+	Sequence uint64 `json:"sequence"`
+	// type URLs of the messages of the transaction
+	Msgs []string `json:"msgs"`
+	// the key supplied through `WithIdempotencyKey`, if any
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
+	// the signed transaction, used to rebroadcast it during recovery
+	TxBytes     []byte         `json:"tx_bytes"`
+	Status      TxStatus       `json:"status"`
+	Code        uint32         `json:"code,omitempty"`
+	Height      int64          `json:"height,omitempty"`
+	Transitions []TxTransition `json:"transitions"`
+}
+
+// Returns the time at which the transaction was signed
+func (e *JournalEntry) CreatedAt() time.Time {
+	if len(e.Transitions) == 0 {
+		return time.Time{}
+	}
+	return e.Transitions[0].At
+}
+
+// Filters the entries returned by `Journal.History`
+type JournalFilter struct {
+	// only return entries of the signer
+	Signer string
+	// only return entries with one of the statuses
+	Statuses []TxStatus
+	// only return entries signed at or after the time
+	Since time.Time
+	// maximum number of entries returned, starting with the most recent, 0 returns all entries
+	Limit int
+}
+
+// An on-disk journal of the transactions sent by the client, recording every signed transaction and its
+// status transitions such that transactions in flight when the process stopped can be resolved on restart
+type Journal struct {
+	db *bolt.DB
+}
+
+// Opens the journal at the given path, creating it if needed
+func OpenJournal(path string) (*Journal, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create journal directory %s", err)
+	}
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open journal %s", err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, bucket := range [][]byte{journalTxsBucket, journalSequenceBucket, journalIdempotencyBucket} {
+			if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to initialize journal %s", err)
+	}
+	return &Journal{db: db}, nil
+}
+
+// Closes the journal
+func (j *Journal) Close() error {
+	return j.db.Close()
+}
+
+// Returns the entry of the transaction with the given hash, or nil if it isn't journaled
+func (j *Journal) Get(hash string) (*JournalEntry, error) {
+	var entry *JournalEntry
+	err := j.db.View(func(tx *bolt.Tx) error {
+		var err error
+		entry, err = getJournalEntry(tx, hash)
+		return err
+	})
+	return entry, err
+}
+
+// Returns the entry of the last transaction sent with the idempotency key, or nil if there is none
+func (j *Journal) GetByIdempotencyKey(key string) (*JournalEntry, error) {
+	var entry *JournalEntry
+	err := j.db.View(func(tx *bolt.Tx) error {
+		hash := tx.Bucket(journalIdempotencyBucket).Get([]byte(key))
+		if hash == nil {
+			return nil
+		}
+		var err error
+		entry, err = getJournalEntry(tx, string(hash))
+		return err
+	})
+	return entry, err
+}
+
+// Returns the journaled transactions matching the filter, most recent first
+func (j *Journal) History(filter JournalFilter) ([]*JournalEntry, error) {
+	var entries []*JournalEntry
+	err := j.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(journalTxsBucket).ForEach(func(_, value []byte) error {
+			var entry JournalEntry
+			if err := json.Unmarshal(value, &entry); err != nil {
+				return err
+			}
+			if filter.matches(&entry) {
+				entries = append(entries, &entry)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read journal %s", err)
+	}
+	sortEntries(entries)
+	if filter.Limit > 0 && len(entries) > filter.Limit {
+		entries = entries[:filter.Limit]
+	}
+	return entries, nil
+}
+
+// Returns the transactions which may still be included in a block, oldest first
+func (j *Journal) Pending() ([]*JournalEntry, error) {
+	entries, err := j.History(JournalFilter{Statuses: []TxStatus{TxStatusSigned, TxStatusBroadcast}})
+	if err != nil {
+		return nil, err
+	}
+	for i, k := 0, len(entries)-1; i < k; i, k = i+1, k-1 {
+		entries[i], entries[k] = entries[k], entries[i]
+	}
+	return entries, nil
+}
+
+// returns the pending transaction of the signer at or above the sequence, if any
+func (j *Journal) pendingFrom(signer string, sequence uint64) (*JournalEntry, error) {
+	var pending *JournalEntry
+	err := j.db.View(func(tx *bolt.Tx) error {
+		cursor := tx.Bucket(journalSequenceBucket).Cursor()
+		prefix := []byte(signer + "/")
+		for key, hash := cursor.Seek(sequenceKey(signer, sequence)); key != nil && hasPrefix(key, prefix); key, hash = cursor.Next() {
+			entry, err := getJournalEntry(tx, string(hash))
+			if err != nil {
+				return err
+			}
+			if entry != nil && entry.Status.Pending() {
+				pending = entry
+				return nil
+			}
+		}
+		return nil
+	})
+	return pending, err
+}
+
+// records a newly signed transaction
+func (j *Journal) record(entry *JournalEntry) error {
+	entry.Status = TxStatusSigned
+	entry.Transitions = []TxTransition{{Status: TxStatusSigned, At: time.Now()}}
+	return j.db.Update(func(tx *bolt.Tx) error {
+		if err := putJournalEntry(tx, entry); err != nil {
+			return err
+		}
+		if entry.IdempotencyKey != "" {
+			if err := tx.Bucket(journalIdempotencyBucket).Put([]byte(entry.IdempotencyKey), []byte(entry.Hash)); err != nil {
+				return err
+			}
+		}
+		return tx.Bucket(journalSequenceBucket).Put(sequenceKey(entry.Signer, entry.Sequence), []byte(entry.Hash))
+	})
+}
+
+// transitions the transaction to the status, applying the update to the entry
+func (j *Journal) transition(hash string, status TxStatus, reason string, update func(*JournalEntry)) error {
+	return j.db.Update(func(tx *bolt.Tx) error {
+		entry, err := getJournalEntry(tx, hash)
+		if err != nil {
+			return err
+		}
+		if entry == nil {
+			return fmt.Errorf("transaction %s is not journaled", hash)
+		}
+		if update != nil {
+			update(entry)
+		}
+		if entry.Status != status {
+			entry.Status = status
+			entry.Transitions = append(entry.Transitions, TxTransition{Status: status, At: time.Now(), Reason: reason})
+		}
+		return putJournalEntry(tx, entry)
+	})
+}
+
+func (f JournalFilter) matches(entry *JournalEntry) bool {
+	if f.Signer != "" && entry.Signer != f.Signer {
+		return false
+	}
+	if !f.Since.IsZero() && entry.CreatedAt().Before(f.Since) {
+		return false
+	}
+	if len(f.Statuses) == 0 {
+		return true
+	}
+	for _, status := range f.Statuses {
+		if entry.Status == status {
+			return true
+		}
+	}
+	return false
+}
+
+// Resolves the transactions which were in flight when the client last stopped. Transactions found on chain
+// are marked as committed or failed. Others are rebroadcast as signed, which can't result in a double-send
+// as a transaction is only ever executed once, unless the sequence of the signer has moved past them in
+// which case they are marked as dropped. Returns the entries which were resolved
+func (c *Client) RecoverJournal(ctx context.Context) ([]*JournalEntry, error) {
+	if err := c.checkClosed(); err != nil {
+		return nil, err
+	}
+	if c.Journal == nil {
+		return nil, fmt.Errorf("journal is not enabled")
+	}
+	c.txLock.Lock()
+	defer c.txLock.Unlock()
+
+	pending, err := c.Journal.Pending()
+	if err != nil {
+		return nil, err
+	}
+	var resolved []*JournalEntry
+	for _, entry := range pending {
+		if entry.ChainID != c.cfg.ChainID {
+			continue
+		}
+		if err := c.recoverTx(ctx, entry); err != nil {
+			c.log.Warn("failed to recover transaction", zap.String("tx.hash", entry.Hash), zap.Error(err))
+			continue
+		}
+		updated, err := c.Journal.Get(entry.Hash)
+		if err != nil {
+			return resolved, err
+		}
+		if !updated.Status.Pending() {
+			resolved = append(resolved, updated)
+		}
+	}
+	return resolved, nil
+}
+
+// resolves a pending transaction, rebroadcasting it if it may still be included
+func (c *Client) recoverTx(ctx context.Context, entry *JournalEntry) error {
+	if c.journalInclusion(ctx, entry.Hash) {
+		return nil
+	}
+	addr, err := c.DecodeBech32AccAddr(entry.Signer)
+	if err != nil {
+		return err
+	}
+	_, sequence, err := c.GetAccountNumberSequence(c.cctx, addr)
+	if err != nil {
+		return fmt.Errorf("failed to query sequence of %s %s", entry.Signer, err)
+	}
+	if sequence > entry.Sequence {
+		// the sequence may have been consumed by the transaction since it was last queried
+		if c.journalInclusion(ctx, entry.Hash) {
+			return nil
+		}
+		return c.Journal.transition(entry.Hash, TxStatusDropped, fmt.Sprintf("signer sequence is %d", sequence), nil)
+	}
+	res, err := c.submitTx(ctx, entry.Hash, entry.TxBytes)
+	if err != nil {
+		return err
+	}
+	return c.confirmTx(ctx, res.TxHash, nil)
+}
+
+// returns true if the transaction was found on chain, in which case its entry is updated
+func (c *Client) journalInclusion(ctx context.Context, hash string) bool {
+	decoded, err := hex.DecodeString(hash)
+	if err != nil {
+		return false
+	}
+	res, err := c.rpcClient().Tx(ctx, decoded, false)
+	if err != nil {
+		return false
+	}
+	c.journalIncluded(hash, res.Height, res.TxResult.Code, res.TxResult.Log)
+	return true
+}
+
+// records a signed transaction, if the journal is enabled
+func (c *Client) journalSigned(txBytes []byte, sequence uint64, msgTypes []string, idempotencyKey string) (string, error) {
+	hash := fmt.Sprintf("%X", comettypes.Tx(txBytes).Hash())
+	if c.Journal == nil {
+		return hash, nil
+	}
+	return hash, c.Journal.record(&JournalEntry{
+		Hash:           hash,
+		ChainID:        c.cfg.ChainID,
+		Signer:         c.FromAddress(),
+		Sequence:       sequence,
+		Msgs:           msgTypes,
+		IdempotencyKey: idempotencyKey,
+		TxBytes:        txBytes,
+	})
+}
+
+func (c *Client) journalBroadcast(hash string) {
+	c.journalTransition(hash, TxStatusBroadcast, "", nil)
+}
+
+func (c *Client) journalRejected(hash string, reason string) {
+	c.journalTransition(hash, TxStatusRejected, reason, nil)
+}
+
+func (c *Client) journalIncluded(hash string, height int64, code uint32, log string) {
+	status, reason := TxStatusCommitted, ""
+	if code != 0 {
+		status, reason = TxStatusFailed, log
+	}
+	c.journalTransition(hash, status, reason, func(entry *JournalEntry) {
+		entry.Height, entry.Code = height, code
+	})
+}
+
+// transitions the entry, logging failures as the transaction itself is unaffected by them
+func (c *Client) journalTransition(hash string, status TxStatus, reason string, update func(*JournalEntry)) {
+	if c.Journal == nil {
+		return
+	}
+	if err := c.Journal.transition(hash, status, reason, update); err != nil {
+		c.log.Warn("failed to update journal", zap.String("tx.hash", hash), zap.Error(err))
+	}
+}
+
+// fails with `ErrPendingTx` if the journal holds an unresolved transaction of the signer which may
+// still be included at or above the sequence
+func (c *Client) checkPendingTx(sequence uint64) error {
+	if c.Journal == nil {
+		return nil
+	}
+	pending, err := c.Journal.pendingFrom(c.FromAddress(), sequence)
+	if err != nil {
+		return fmt.Errorf("failed to read journal %s", err)
+	}
+	if pending != nil {
+		return fmt.Errorf("%w %s at sequence %d is %s, recover the journal before sending", ErrPendingTx, pending.Hash, pending.Sequence, pending.Status)
+	}
+	return nil
+}
+
+// returns the path of the journal, or an empty string if it is disabled
+func (ccc *ClientConfig) journalPath() string {
+	if !ccc.Journal {
+		return ""
+	}
+	if ccc.JournalPath != "" {
+		return ccc.JournalPath
+	}
+	return filepath.Join(ccc.KeyDirectory, DefaultJournalFile)
+}
+
+func getJournalEntry(tx *bolt.Tx, hash string) (*JournalEntry, error) {
+	value := tx.Bucket(journalTxsBucket).Get([]byte(hash))
+	if value == nil {
+		return nil, nil
+	}
+	var entry JournalEntry
+	if err := json.Unmarshal(value, &entry); err != nil {
+		return nil, fmt.Errorf("failed to decode journal entry %s", err)
+	}
+	return &entry, nil
+}
+
+func putJournalEntry(tx *bolt.Tx, entry *JournalEntry) error {
+	value, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(journalTxsBucket).Put([]byte(entry.Hash), value)
+}
+
+// returns the key of the sequence index, ordering the sequences of a signer numerically
+func sequenceKey(signer string, sequence uint64) []byte {
+	key := []byte(signer + "/")
+	return binary.BigEndian.AppendUint64(key, sequence)
+}
+
+func hasPrefix(key, prefix []byte) bool {
+	return len(key) >= len(prefix) && string(key[:len(prefix)]) == string(prefix)
+}
+
+// sorts entries by the time they were signed, most recent first
+func sortEntries(entries []*JournalEntry) {
+	slices.SortStableFunc(entries, func(a, b *JournalEntry) bool {
+		return a.CreatedAt().After(b.CreatedAt())
+	})
+}
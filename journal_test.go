@@ -0,0 +1,164 @@
+package compass_test
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	comettypes "github.com/cometbft/cometbft/types"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/stretchr/testify/require"
+	"github.com/teamscanworks/compass"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// serves accounts with the given sequence
+type accountService struct {
+	authtypes.UnimplementedQueryServer
+	sequence uint64
+}
+
+func (s *accountService) Account(ctx context.Context, req *authtypes.QueryAccountRequest) (*authtypes.QueryAccountResponse, error) {
+	if err := grpc.SetHeader(ctx, metadata.Pairs(grpctypes.GRPCBlockHeightHeader, "1")); err != nil {
+		return nil, err
+	}
+	acc, err := codectypes.NewAnyWithValue(&authtypes.BaseAccount{Address: req.Address, AccountNumber: 1, Sequence: s.sequence})
+	if err != nil {
+		return nil, err
+	}
+	return &authtypes.QueryAccountResponse{Account: acc}, nil
+}
+
+func TestJournal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal", "journal.db")
+	journal, err := compass.OpenJournal(path)
+	require.NoError(t, err)
+
+	for i, signer := range []string{"alice", "bob", "alice"} {
+		require.NoError(t, journal.Record(&compass.JournalEntry{
+			Hash:     "HASH" + strconv.Itoa(i),
+			Signer:   signer,
+			Sequence: uint64(i),
+			Msgs:     []string{"/cosmos.bank.v1beta1.MsgSend"},
+		}))
+	}
+	require.NoError(t, journal.Transition("HASH0", compass.TxStatusBroadcast))
+	require.NoError(t, journal.Transition("HASH0", compass.TxStatusCommitted))
+	require.Error(t, journal.Transition("MISSING", compass.TxStatusCommitted))
+	require.NoError(t, journal.Close())
+
+	// entries persist across restarts
+	journal, err = compass.OpenJournal(path)
+	require.NoError(t, err)
+	defer journal.Close()
+
+	entry, err := journal.Get("HASH0")
+	require.NoError(t, err)
+	require.Equal(t, compass.TxStatusCommitted, entry.Status)
+	require.Equal(t, []compass.TxStatus{compass.TxStatusSigned, compass.TxStatusBroadcast, compass.TxStatusCommitted}, []compass.TxStatus{
+		entry.Transitions[0].Status, entry.Transitions[1].Status, entry.Transitions[2].Status,
+	})
+	missing, err := journal.Get("MISSING")
+	require.NoError(t, err)
+	require.Nil(t, missing)
+
+	history, err := journal.History(compass.JournalFilter{Signer: "alice"})
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	require.Equal(t, "HASH2", history[0].Hash)
+	history, err = journal.History(compass.JournalFilter{Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	require.Equal(t, "HASH2", history[0].Hash)
+
+	pending, err := journal.Pending()
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	require.Equal(t, "HASH1", pending[0].Hash)
+	require.Equal(t, "HASH2", pending[1].Hash)
+}
+
+// returns a client with a journal and signing key, connected to a node whose accounts are at sequence 5
+func newJournaledClient(t *testing.T, register ...func(*grpc.Server)) (*compass.Client, *fakeNode) {
+	logger, err := zap.NewDevelopment()
+	require.NoError(t, err)
+	node := newFakeNode(t, "testing")
+	register = append(register, func(srv *grpc.Server) {
+		authtypes.RegisterQueryServer(srv, &accountService{sequence: 5})
+	})
+	cfg := newTestConfig(node.srv.URL, newNodeInfoServer(t, "testing", register...))
+	cfg.KeyDirectory = t.TempDir()
+	cfg.Journal = true
+	client, err := compass.NewClient(logger, cfg, []keyring.Option{compass.DefaultSignatureOptions()})
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close(context.Background()) })
+	require.FileExists(t, filepath.Join(cfg.KeyDirectory, compass.DefaultJournalFile))
+	_, err = client.AddKey("default", 118)
+	require.NoError(t, err)
+	require.NoError(t, client.SetFromAddress())
+	return client, node
+}
+
+func TestRecoverJournal(t *testing.T) {
+	client, node := newJournaledClient(t)
+	signer := client.FromAddress()
+
+	const included, dropped, pending = "AA01", "AA02", "AA03"
+	entries := map[string]uint64{included: 3, dropped: 4, pending: 5}
+	for hash, sequence := range entries {
+		require.NoError(t, client.Journal.Record(&compass.JournalEntry{Hash: hash, ChainID: "testing", Signer: signer, Sequence: sequence}))
+	}
+	node.txs[included] = abci.ExecTxResult{Code: 0}
+
+	// a transaction which may still be included at the current sequence blocks sending
+	_, err := client.SendTransaction(context.Background(), &authtypes.BaseAccount{})
+	require.ErrorIs(t, err, compass.ErrPendingTx)
+
+	require.NoError(t, client.Journal.Transition(pending, compass.TxStatusRejected))
+	resolved, err := client.RecoverJournal(context.Background())
+	require.NoError(t, err)
+	require.Len(t, resolved, 2)
+	entry, err := client.Journal.Get(included)
+	require.NoError(t, err)
+	require.Equal(t, compass.TxStatusCommitted, entry.Status)
+	require.Equal(t, int64(1), entry.Height)
+	entry, err = client.Journal.Get(dropped)
+	require.NoError(t, err)
+	require.Equal(t, compass.TxStatusDropped, entry.Status)
+}
+
+func TestRecoverJournalRebroadcast(t *testing.T) {
+	client, node := newJournaledClient(t)
+	signer := client.FromAddress()
+
+	// a transaction signed at the current sequence, which the node lost before including it
+	txBytes := []byte("signed transaction")
+	hash := fmt.Sprintf("%X", comettypes.Tx(txBytes).Hash())
+	require.NoError(t, client.Journal.Record(&compass.JournalEntry{
+		Hash:     hash,
+		ChainID:  "testing",
+		Signer:   signer,
+		Sequence: 5,
+		TxBytes:  txBytes,
+	}))
+	require.NoError(t, client.Journal.Transition(hash, compass.TxStatusBroadcast))
+	require.NotContains(t, node.txs, hash)
+
+	resolved, err := client.RecoverJournal(context.Background())
+	require.NoError(t, err)
+	require.Len(t, resolved, 1)
+	require.Equal(t, hash, resolved[0].Hash)
+	require.Equal(t, compass.TxStatusCommitted, resolved[0].Status)
+	require.Contains(t, node.txs, hash)
+	pending, err := client.Journal.Pending()
+	require.NoError(t, err)
+	require.Empty(t, pending)
+}
@@ -0,0 +1,134 @@
+package compass
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec/legacy"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// ErrKeyNotFound is matched by all `KeyNotFoundError`s when using `errors.Is`
+var ErrKeyNotFound = errors.New("key not found")
+
+// Returned when a key lookup does not match any key in the keyring
+type KeyNotFoundError struct {
+	// the attribute used for the lookup, ie: name, address, pubkey or index
+	By    string
+	Value string
+}
+
+func (e *KeyNotFoundError) Error() string {
+	return fmt.Sprintf("key with %s %s not found", e.By, e.Value)
+}
+
+func (e *KeyNotFoundError) Is(target error) bool {
+	return target == ErrKeyNotFound
+}
+
+// Describes a key stored in the keyring
+type KeyInfo struct {
+	Name string `json:"name" yaml:"name"`
+	// one of local, ledger, offline or multi
+	Type string `json:"type" yaml:"type"`
+	Algo string `json:"algo" yaml:"algo"`
+	// bech32 encoded account address
+	Address string `json:"address" yaml:"address"`
+	// bech32 encoded public key
+	PubKey    string `json:"pubkey" yaml:"pubkey"`
+	PubKeyHex string `json:"pubkey-hex" yaml:"pubkey-hex"`
+}
+
+// Returns information about the key with the given name
+func (cc *Client) KeyByName(name string) (*KeyInfo, error) {
+	record, err := cc.Keyring.Key(name)
+	if err != nil {
+		return nil, keyLookupError(err, "name", name)
+	}
+	return cc.newKeyInfo(record)
+}
+
+// Returns information about the key with the given bech32 encoded account address
+func (cc *Client) KeyByAddress(address string) (*KeyInfo, error) {
+	addr, err := cc.DecodeBech32AccAddr(address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode address %s", err)
+	}
+	record, err := cc.Keyring.KeyByAddress(addr)
+	if err != nil {
+		return nil, keyLookupError(err, "address", address)
+	}
+	return cc.newKeyInfo(record)
+}
+
+// Returns information about the key with the given public key
+func (cc *Client) KeyByPubKey(pubKey cryptotypes.PubKey) (*KeyInfo, error) {
+	record, err := cc.Keyring.KeyByAddress(sdk.AccAddress(pubKey.Address()))
+	if err != nil {
+		return nil, keyLookupError(err, "pubkey", hex.EncodeToString(pubKey.Bytes()))
+	}
+	return cc.newKeyInfo(record)
+}
+
+// Returns information about all keys in the keyring
+func (cc *Client) ListKeys() ([]*KeyInfo, error) {
+	records, err := cc.Keyring.List()
+	if err != nil {
+		return nil, err
+	}
+	infos := make([]*KeyInfo, 0, len(records))
+	for _, record := range records {
+		info, err := cc.newKeyInfo(record)
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+// Returns the bech32 encoding of the public key, using the account prefix suffixed by `pub`
+func (cc *Client) EncodeBech32PubKey(pubKey cryptotypes.PubKey) (string, error) {
+	bz, err := legacy.Cdc.Marshal(pubKey)
+	if err != nil {
+		return "", err
+	}
+	return bech32.ConvertAndEncode(fmt.Sprintf("%s%s", cc.cfg.AccountPrefix, "pub"), bz)
+}
+
+// converts a keyring record into its KeyInfo representation
+func (cc *Client) newKeyInfo(record *keyring.Record) (*KeyInfo, error) {
+	pubKey, err := record.GetPubKey()
+	if err != nil {
+		return nil, err
+	}
+	address, err := cc.EncodeBech32AccAddr(sdk.AccAddress(pubKey.Address()))
+	if err != nil {
+		return nil, err
+	}
+	bech32PubKey, err := cc.EncodeBech32PubKey(pubKey)
+	if err != nil {
+		return nil, err
+	}
+	return &KeyInfo{
+		Name:      record.Name,
+		Type:      record.GetType().String(),
+		Algo:      pubKey.Type(),
+		Address:   address,
+		PubKey:    bech32PubKey,
+		PubKeyHex: hex.EncodeToString(pubKey.Bytes()),
+	}, nil
+}
+
+// converts keyring not found errors into a KeyNotFoundError
+func keyLookupError(err error, by, value string) error {
+	if errors.Is(err, sdkerrors.ErrKeyNotFound) {
+		return &KeyNotFoundError{By: by, Value: value}
+	}
+	return err
+}
@@ -0,0 +1,78 @@
+package compass_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	"github.com/stretchr/testify/require"
+	"github.com/teamscanworks/compass"
+)
+
+func TestKeyLookup(t *testing.T) {
+	client, _ := newMemoryClient(t)
+	ko, err := client.AddKey("default", 118)
+	require.NoError(t, err)
+	record, err := client.Keyring.Key("default")
+	require.NoError(t, err)
+	pubKey, err := record.GetPubKey()
+	require.NoError(t, err)
+
+	byName, err := client.KeyByName("default")
+	require.NoError(t, err)
+	require.Equal(t, "default", byName.Name)
+	require.Equal(t, "local", byName.Type)
+	require.Equal(t, "secp256k1", byName.Algo)
+	require.Equal(t, ko.Address, byName.Address)
+	require.True(t, strings.HasPrefix(byName.PubKey, "cosmospub1"))
+	require.Len(t, byName.PubKeyHex, 66)
+
+	byAddress, err := client.KeyByAddress(ko.Address)
+	require.NoError(t, err)
+	require.Equal(t, byName, byAddress)
+
+	byPubKey, err := client.KeyByPubKey(pubKey)
+	require.NoError(t, err)
+	require.Equal(t, byName, byPubKey)
+
+	require.NoError(t, client.ImportPubKeyHex("watch", "02"+strings.Repeat("11", 32)))
+	watch, err := client.KeyByName("watch")
+	require.NoError(t, err)
+	require.Equal(t, keyring.TypeOffline.String(), watch.Type)
+
+	keys, err := client.ListKeys()
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+
+	t.Run("NotFound", func(t *testing.T) {
+		var notFound *compass.KeyNotFoundError
+		_, err := client.KeyByName("missing")
+		require.ErrorIs(t, err, compass.ErrKeyNotFound)
+		require.True(t, errors.As(err, &notFound))
+		require.Equal(t, "name", notFound.By)
+
+		_, err = client.KeyByPubKey(secp256k1.GenPrivKey().PubKey())
+		require.ErrorIs(t, err, compass.ErrKeyNotFound)
+
+		missing, err := client.EncodeBech32AccAddr(secp256k1.GenPrivKey().PubKey().Address().Bytes())
+		require.NoError(t, err)
+		_, err = client.KeyByAddress(missing)
+		require.ErrorIs(t, err, compass.ErrKeyNotFound)
+
+		_, err = client.KeyByAddress("not-an-address")
+		require.Error(t, err)
+		require.NotErrorIs(t, err, compass.ErrKeyNotFound)
+	})
+	t.Run("RecordAt", func(t *testing.T) {
+		for _, idx := range []int{0, 1} {
+			_, err := client.KeyringRecordAt(idx)
+			require.NoError(t, err)
+		}
+		for _, idx := range []int{-1, 2, 3} {
+			_, err := client.KeyringRecordAt(idx)
+			require.ErrorIs(t, err, compass.ErrKeyNotFound)
+		}
+	})
+}
@@ -1,10 +1,22 @@
 package compass
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
 	ckeys "github.com/cosmos/cosmos-sdk/client/keys"
 
+	"github.com/cosmos/cosmos-sdk/crypto"
 	"github.com/cosmos/cosmos-sdk/crypto/hd"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/go-bip39"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 )
 
 // KeyOutput contains mnemonic and address of key
@@ -86,6 +98,93 @@ func (cc *Client) ExportPrivKeyArmor(keyName string) (armor string, err error) {
 	return cc.Keyring.ExportPrivKeyArmor(keyName, ckeys.DefaultKeyPass)
 }
 
+// Returns the private key in ASCII armored format, encrypted with the given passphrase
+func (cc *Client) ExportPrivKeyArmorEncrypted(keyName, passphrase string) (armor string, err error) {
+	if passphrase == "" {
+		return "", fmt.Errorf("empty passphrase")
+	}
+	return cc.Keyring.ExportPrivKeyArmor(keyName, passphrase)
+}
+
+// Imports an ASCII armored private key which was encrypted with the given passphrase
+func (cc *Client) ImportPrivKeyArmor(keyName, armor, passphrase string) error {
+	return cc.Keyring.ImportPrivKey(keyName, armor, passphrase)
+}
+
+// Returns the public key in ASCII armored format
+func (cc *Client) ExportPubKeyArmor(keyName string) (armor string, err error) {
+	return cc.Keyring.ExportPubKeyArmor(keyName)
+}
+
+// Imports an ASCII armored public key as a read-only (offline) record, which can be
+// used for watching an address but not for signing
+func (cc *Client) ImportPubKeyArmor(keyName, armor string) error {
+	return cc.Keyring.ImportPubKey(keyName, armor)
+}
+
+// Imports the public key as a read-only (offline) record, which can be used for
+// watching an address but not for signing
+func (cc *Client) ImportPubKey(keyName string, pubKey cryptotypes.PubKey) error {
+	if cc.KeyExists(keyName) {
+		return fmt.Errorf("key %s already exists", keyName)
+	}
+	_, err := cc.Keyring.SaveOfflineKey(keyName, pubKey)
+	return err
+}
+
+// Imports a hex encoded, compressed secp256k1 public key as a read-only (offline) record
+func (cc *Client) ImportPubKeyHex(keyName, pubKeyHex string) error {
+	bz, err := hex.DecodeString(strings.TrimPrefix(pubKeyHex, "0x"))
+	if err != nil {
+		return fmt.Errorf("failed to decode public key %s", err)
+	}
+	if len(bz) != secp256k1.PubKeySize {
+		return fmt.Errorf("invalid public key length %v, expected %v", len(bz), secp256k1.PubKeySize)
+	}
+	return cc.ImportPubKey(keyName, &secp256k1.PubKey{Key: bz})
+}
+
+// Returns the hex encoded raw secp256k1 private key, intended for migrating keys to other tools.
+//
+// NOTE: the returned key is unencrypted, so care must be taken in handling it
+func (cc *Client) ExportPrivKeyHex(keyName string) (string, error) {
+	// the keyring does not expose private keys directly, so round-trip through
+	// armoring with a one-time passphrase
+	passphrase, err := oneTimePassphrase()
+	if err != nil {
+		return "", err
+	}
+	armor, err := cc.Keyring.ExportPrivKeyArmor(keyName, passphrase)
+	if err != nil {
+		return "", err
+	}
+	privKey, algo, err := crypto.UnarmorDecryptPrivKey(armor, passphrase)
+	if err != nil {
+		return "", err
+	}
+	if algo != string(hd.Secp256k1Type) {
+		return "", fmt.Errorf("unsupported key algorithm %s", algo)
+	}
+	return hex.EncodeToString(privKey.Bytes()), nil
+}
+
+// Imports a hex encoded raw secp256k1 private key, intended for migrating keys from other tools
+func (cc *Client) ImportPrivKeyHex(keyName, privKeyHex string) error {
+	bz, err := hex.DecodeString(strings.TrimPrefix(privKeyHex, "0x"))
+	if err != nil {
+		return fmt.Errorf("failed to decode private key %s", err)
+	}
+	if len(bz) != secp256k1.PrivKeySize {
+		return fmt.Errorf("invalid private key length %v, expected %v", len(bz), secp256k1.PrivKeySize)
+	}
+	passphrase, err := oneTimePassphrase()
+	if err != nil {
+		return err
+	}
+	armor := crypto.EncryptArmorPrivKey(&secp256k1.PrivKey{Key: bz}, passphrase, string(hd.Secp256k1Type))
+	return cc.Keyring.ImportPrivKey(keyName, armor, passphrase)
+}
+
 func (cc *Client) KeyAddOrRestore(keyName string, coinType uint32, mnemonic ...string) (*KeyOutput, error) {
 	var mnemonicStr string
 	var err error
@@ -116,3 +215,78 @@ func (cc *Client) KeyAddOrRestore(keyName string, coinType uint32, mnemonic ...s
 	}
 	return &KeyOutput{Mnemonic: mnemonicStr, Address: out}, nil
 }
+
+// Seeds the keyring with the given mnemonics, keyed by the name of the key to create. This
+// is primarily intended for populating the ephemeral `memory` keyring backend. Every entry is
+// validated before any key is imported, and keys are imported in the order of their names
+func (cc *Client) SeedFromMnemonics(mnemonics map[string]string, coinType uint32) error {
+	names, err := cc.seedNames(mnemonics, func(mnemonic string) error {
+		if !bip39.IsMnemonicValid(mnemonic) {
+			return fmt.Errorf("invalid mnemonic")
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		if _, err := cc.KeyAddOrRestore(name, coinType, mnemonics[name]); err != nil {
+			return fmt.Errorf("failed to seed key %s %v", name, err)
+		}
+	}
+	return nil
+}
+
+// Seeds the keyring with the given armored private keys, keyed by the name of the key to create,
+// using the passphrase the keys were armored with. This is primarily intended for populating the
+// ephemeral `memory` keyring backend. Every entry is validated before any key is imported, and
+// keys are imported in the order of their names
+func (cc *Client) SeedFromArmor(armors map[string]string, passphrase string) error {
+	names, err := cc.seedNames(armors, func(armor string) error {
+		_, _, err := crypto.UnarmorDecryptPrivKey(armor, passphrase)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		if err := cc.ImportPrivKeyArmor(name, armors[name], passphrase); err != nil {
+			return fmt.Errorf("failed to seed key %s %v", name, err)
+		}
+	}
+	return nil
+}
+
+// validates the entries used for seeding the keyring, returning the sorted names of the keys
+// to create
+func (cc *Client) seedNames(entries map[string]string, validate func(string) error) ([]string, error) {
+	names := maps.Keys(entries)
+	slices.Sort(names)
+	for _, name := range names {
+		if cc.KeyExists(name) {
+			return nil, fmt.Errorf("failed to seed key %s already exists", name)
+		}
+		if err := validate(entries[name]); err != nil {
+			return nil, fmt.Errorf("failed to seed key %s %v", name, err)
+		}
+	}
+	return names, nil
+}
+
+// returns a random passphrase used for transiently armoring private keys
+func oneTimePassphrase() (string, error) {
+	bz := make([]byte, 32)
+	if _, err := rand.Read(bz); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bz), nil
+}
+
+// Returns the coin type used when deriving new keys, defaulting to the cosmos coin type
+// when `ClientConfig.Slip44` is unset
+func (cc *Client) coinType() uint32 {
+	if cc.cfg.Slip44 > 0 {
+		return uint32(cc.cfg.Slip44)
+	}
+	return sdk.CoinType
+}
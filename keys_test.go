@@ -0,0 +1,133 @@
+package compass_test
+
+import (
+	"context"
+	"encoding/hex"
+	"path/filepath"
+	"testing"
+
+	ckeys "github.com/cosmos/cosmos-sdk/client/keys"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/stretchr/testify/require"
+	"github.com/teamscanworks/compass"
+	"go.uber.org/zap"
+)
+
+// returns a client using the given keyring backend, which stores keys in a temporary directory
+func newBackendClient(t *testing.T, backend string) (*compass.Client, *compass.ClientConfig) {
+	logger, err := zap.NewDevelopment()
+	require.NoError(t, err)
+	cfg := compass.GetSimdConfig()
+	cfg.KeyringBackend = backend
+	cfg.KeyDirectory = filepath.Join(t.TempDir(), "keys")
+	cfg.Passphrase = compass.StaticPassphrase("supersecret")
+	client, err := compass.NewClient(logger, cfg, []keyring.Option{compass.DefaultSignatureOptions()})
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close(context.Background()) })
+	return client, cfg
+}
+
+func newMemoryClient(t *testing.T) (*compass.Client, *compass.ClientConfig) {
+	return newBackendClient(t, keyring.BackendMemory)
+}
+
+func TestMemoryKeyring(t *testing.T) {
+	source, cfg := newMemoryClient(t)
+	ko, err := source.AddKey("default", 118)
+	require.NoError(t, err)
+	require.NoDirExists(t, cfg.KeyDirectory)
+
+	t.Run("SeedFromMnemonics", func(t *testing.T) {
+		client, _ := newMemoryClient(t)
+		require.NoError(t, client.SeedFromMnemonics(map[string]string{"seeded": ko.Mnemonic}, 118))
+		address, err := client.ShowAddress("seeded")
+		require.NoError(t, err)
+		require.Equal(t, ko.Address, address)
+
+		// an invalid entry or an existing key fails seeding before any key is imported
+		for _, mnemonics := range []map[string]string{
+			{"a": ko.Mnemonic, "b": "invalid mnemonic", "c": ko.Mnemonic},
+			{"a": ko.Mnemonic, "seeded": ko.Mnemonic},
+		} {
+			require.Error(t, client.SeedFromMnemonics(mnemonics, 118))
+			for _, name := range []string{"a", "b", "c"} {
+				require.False(t, client.KeyExists(name))
+			}
+		}
+	})
+	t.Run("SeedFromArmor", func(t *testing.T) {
+		armor, err := source.ExportPrivKeyArmor("default")
+		require.NoError(t, err)
+		client, _ := newMemoryClient(t)
+		require.NoError(t, client.SeedFromArmor(map[string]string{"seeded": armor}, ckeys.DefaultKeyPass))
+		address, err := client.ShowAddress("seeded")
+		require.NoError(t, err)
+		require.Equal(t, ko.Address, address)
+
+		require.Error(t, client.SeedFromArmor(map[string]string{"a": armor, "b": "invalid armor"}, ckeys.DefaultKeyPass))
+		require.Error(t, client.SeedFromArmor(map[string]string{"a": armor}, "wrong passphrase"))
+		require.False(t, client.KeyExists("a"))
+	})
+}
+
+func TestKeyImportExport(t *testing.T) {
+	for _, backend := range []string{keyring.BackendTest, keyring.BackendFile, keyring.BackendMemory} {
+		backend := backend
+		t.Run(backend, func(t *testing.T) {
+			source, _ := newBackendClient(t, backend)
+			ko, err := source.AddKey("default", 118)
+			require.NoError(t, err)
+			dest, _ := newBackendClient(t, backend)
+
+			t.Run("PrivKeyArmor", func(t *testing.T) {
+				_, err := source.ExportPrivKeyArmorEncrypted("default", "")
+				require.Error(t, err)
+				armor, err := source.ExportPrivKeyArmorEncrypted("default", "hunter22")
+				require.NoError(t, err)
+				require.Error(t, dest.ImportPrivKeyArmor("armor", armor, "incorrect"))
+				require.NoError(t, dest.ImportPrivKeyArmor("armor", armor, "hunter22"))
+				address, err := dest.ShowAddress("armor")
+				require.NoError(t, err)
+				require.Equal(t, ko.Address, address)
+			})
+			t.Run("PrivKeyHex", func(t *testing.T) {
+				privHex, err := source.ExportPrivKeyHex("default")
+				require.NoError(t, err)
+				require.Len(t, privHex, 64)
+				require.Error(t, dest.ImportPrivKeyHex("hex", "abcd"))
+				require.NoError(t, dest.ImportPrivKeyHex("hex", privHex))
+				address, err := dest.ShowAddress("hex")
+				require.NoError(t, err)
+				require.Equal(t, ko.Address, address)
+				roundTrip, err := dest.ExportPrivKeyHex("hex")
+				require.NoError(t, err)
+				require.Equal(t, privHex, roundTrip)
+			})
+			t.Run("PubKeyArmor", func(t *testing.T) {
+				armor, err := source.ExportPubKeyArmor("default")
+				require.NoError(t, err)
+				require.NoError(t, dest.ImportPubKeyArmor("watch", armor))
+				address, err := dest.ShowAddress("watch")
+				require.NoError(t, err)
+				require.Equal(t, ko.Address, address)
+				record, err := dest.Keyring.Key("watch")
+				require.NoError(t, err)
+				require.Equal(t, keyring.TypeOffline, record.GetType())
+				// watch-only records are unable to export private key material
+				_, err = dest.ExportPrivKeyHex("watch")
+				require.Error(t, err)
+			})
+			t.Run("PubKeyHex", func(t *testing.T) {
+				record, err := source.Keyring.Key("default")
+				require.NoError(t, err)
+				pubKey, err := record.GetPubKey()
+				require.NoError(t, err)
+				require.NoError(t, dest.ImportPubKeyHex("watch-hex", hex.EncodeToString(pubKey.Bytes())))
+				require.Error(t, dest.ImportPubKeyHex("watch-hex", hex.EncodeToString(pubKey.Bytes())))
+				address, err := dest.ShowAddress("watch-hex")
+				require.NoError(t, err)
+				require.Equal(t, ko.Address, address)
+			})
+		})
+	}
+}
@@ -0,0 +1,345 @@
+package compass
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	sdkmath "cosmossdk.io/math"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"go.uber.org/zap"
+	"golang.org/x/exp/slices"
+)
+
+// maximum number of transactions returned by a node when listing its mempool
+const maxUnconfirmedTxs = 100
+
+// Fee multiplier commonly accepted by mempools supporting the replacement of transactions
+const DefaultFeeBump = 1.1
+
+// Summarizes the mempool of the node
+type MempoolStatus struct {
+	// number of transactions in the mempool
+	Txs int
+	// total size of the transactions in bytes
+	Bytes int64
+}
+
+// A transaction in the mempool of the node
+type UnconfirmedTx struct {
+	// hex encoded hash of the transaction
+	Hash  string
+	Bytes []byte
+}
+
+// Returns the number and size of transactions in the mempool of the node
+func (c *Client) MempoolStatus(ctx context.Context) (*MempoolStatus, error) {
+	res, err := c.rpcClient().NumUnconfirmedTxs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query mempool %s", err)
+	}
+	return &MempoolStatus{Txs: res.Total, Bytes: res.TotalBytes}, nil
+}
+
+// Returns up to `limit` transactions from the mempool of the node, in the order they will be proposed. The node
+// returns at most 100 transactions, which is also used when `limit` is 0
+func (c *Client) UnconfirmedTxs(ctx context.Context, limit int) ([]UnconfirmedTx, error) {
+	if limit <= 0 || limit > maxUnconfirmedTxs {
+		limit = maxUnconfirmedTxs
+	}
+	res, err := c.rpcClient().UnconfirmedTxs(ctx, &limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query mempool %s", err)
+	}
+	txs := make([]UnconfirmedTx, 0, len(res.Txs))
+	for _, tx := range res.Txs {
+		txs = append(txs, UnconfirmedTx{Hash: fmt.Sprintf("%X", tx.Hash()), Bytes: tx})
+	}
+	return txs, nil
+}
+
+// Returns the hashes which belong to transactions in the mempool of the node. Only the first 100 transactions
+// of the mempool are inspected, such that transactions far back in a congested mempool are not detected
+func (c *Client) PendingTxs(ctx context.Context, hashes ...string) ([]string, error) {
+	txs, err := c.UnconfirmedTxs(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	var pending []string
+	for _, hash := range hashes {
+		if slices.ContainsFunc(txs, func(tx UnconfirmedTx) bool { return tx.Hash == hash }) {
+			pending = append(pending, hash)
+		}
+	}
+	return pending, nil
+}
+
+// returns true if the transaction is in the mempool of the node
+func (c *Client) inMempool(ctx context.Context, hash string) (bool, error) {
+	pending, err := c.PendingTxs(ctx, hash)
+	return len(pending) > 0, err
+}
+
+// Replaces a pending transaction of the signer with one including the same messages at the same sequence, paying
+// the given fee which must exceed that of the pending transaction. Replacement is only possible when the mempool
+// of the node supports it (ie: the priority nonce mempool of the application), otherwise the replacement is
+// rejected with a sequence mismatch. Transactions including messages unknown to the codec are replaced by signing
+// their body again, which requires direct signing. Returns the hash of the replacement once it is confirmed
+func (c *Client) ReplaceTx(ctx context.Context, hash string, fee sdk.Coins) (string, error) {
+	return c.replaceTx(ctx, hash, false, func(*DecodedTx) (sdk.Coins, error) {
+		return fee, nil
+	})
+}
+
+// Replaces a pending transaction of the signer with one paying its fee multiplied by `multiplier`,
+// see `ReplaceTx`
+func (c *Client) BumpFee(ctx context.Context, hash string, multiplier float64) (string, error) {
+	return c.replaceTx(ctx, hash, false, func(stuck *DecodedTx) (sdk.Coins, error) {
+		return bumpFee(stuck.Fee, multiplier)
+	})
+}
+
+// Cancels a pending transaction of the signer by replacing it with a send of a single unit of the fee denom to the
+// signer at the same sequence, paying the fee of the pending transaction multiplied by `multiplier`, see `ReplaceTx`.
+// The send has no effect besides the fee, and consumes the sequence of the cancelled transaction
+func (c *Client) CancelTx(ctx context.Context, hash string, multiplier float64) (string, error) {
+	return c.replaceTx(ctx, hash, true, func(stuck *DecodedTx) (sdk.Coins, error) {
+		return bumpFee(stuck.Fee, multiplier)
+	})
+}
+
+// replaces the pending transaction with one paying the fee returned by `replacementFee`, signed at the same
+// sequence. The replacement includes the same messages, or a minimal send to the signer when cancelling
+func (c *Client) replaceTx(
+	ctx context.Context,
+	hash string,
+	cancel bool,
+	replacementFee func(stuck *DecodedTx) (sdk.Coins, error),
+) (string, error) {
+	if err := c.checkClosed(); err != nil {
+		return "", err
+	}
+	c.txLock.Lock()
+	defer c.txLock.Unlock()
+
+	if c.journalInclusion(ctx, hash) {
+		return "", fmt.Errorf("transaction %s was already included", hash)
+	}
+	txBytes, err := c.pendingTxBytes(ctx, hash)
+	if err != nil {
+		return "", err
+	}
+	stuck, err := c.DecodeTxContext(ctx, txBytes)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode transaction %s", err)
+	}
+	if len(stuck.Signatures) != 1 || !slices.Contains(stuck.Signers, c.FromAddress()) {
+		return "", fmt.Errorf("transaction %s is not signed by %s alone", hash, c.FromAddress())
+	}
+	sequence := stuck.Signatures[0].Sequence
+	fee, err := replacementFee(stuck)
+	if err != nil {
+		return "", err
+	}
+	if !fee.IsAllGT(stuck.Fee) {
+		return "", fmt.Errorf("replacement fee %s must exceed the fee %s of the pending transaction", fee, stuck.Fee)
+	}
+
+	factory, err := c.factory.Prepare(c.cctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to prepare transaction %s", err)
+	}
+	factory = factory.
+		WithSequence(sequence).
+		WithGas(stuck.GasLimit).
+		WithGasPrices("").
+		WithFees(fee.String()).
+		WithMemo(stuck.Memo).
+		WithTimeoutHeight(stuck.TimeoutHeight)
+	msgs, known := decodedMsgs(stuck)
+	idempotencyKey := ""
+	if !cancel {
+		// the replacement has the same effect, so sends retried with the key of the original resume it
+		idempotencyKey = c.idempotencyKeyOf(hash)
+	}
+	typeURLs := make([]string, 0, len(stuck.Msgs))
+	for _, msg := range stuck.Msgs {
+		typeURLs = append(typeURLs, msg.TypeURL)
+	}
+	var signedBytes []byte
+	switch {
+	case cancel:
+		signer := c.FromAddress()
+		// sends must transfer a positive amount to pass validation
+		amount := sdk.NewCoins(sdk.NewInt64Coin(fee[0].Denom, 1))
+		msgs = []sdk.Msg{&banktypes.MsgSend{FromAddress: signer, ToAddress: signer, Amount: amount}}
+		typeURLs = msgTypeURLs(msgs)
+		signedBytes, err = c.buildSignedTx(ctx, factory, msgs)
+	case known:
+		signedBytes, err = c.buildSignedTx(ctx, factory, msgs)
+	default:
+		// messages unknown to the codec can't be rebuilt, so the body of the pending transaction is signed again
+		signedBytes, err = c.resignTx(ctx, factory, txBytes, stuck)
+	}
+	if err != nil {
+		return "", err
+	}
+	replacementHash, err := c.journalSigned(signedBytes, sequence, typeURLs, idempotencyKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to journal transaction %s", err)
+	}
+	c.rememberIdempotentTx(idempotencyKey, replacementHash)
+	if _, err := c.submitTx(ctx, replacementHash, signedBytes); err != nil {
+		return "", err
+	}
+	if err := c.confirmTx(ctx, replacementHash, fee); err != nil {
+		return "", err
+	}
+	c.journalTransition(hash, TxStatusDropped, "replaced by "+replacementHash, nil)
+	c.log.Info("replaced transaction", zap.String("tx.hash", hash), zap.String("replacement.hash", replacementHash))
+	return replacementHash, nil
+}
+
+// returns the bytes of a pending transaction, from the mempool of the node or the journal
+func (c *Client) pendingTxBytes(ctx context.Context, hash string) ([]byte, error) {
+	txs, err := c.UnconfirmedTxs(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	for _, tx := range txs {
+		if tx.Hash == hash {
+			return tx.Bytes, nil
+		}
+	}
+	if c.Journal != nil {
+		entry, err := c.Journal.Get(hash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read journal %s", err)
+		}
+		if entry != nil && entry.Status.Pending() {
+			return entry.TxBytes, nil
+		}
+	}
+	return nil, fmt.Errorf("transaction %s is not pending", hash)
+}
+
+// returns the idempotency key the transaction was sent with, if any
+func (c *Client) idempotencyKeyOf(hash string) string {
+	if c.Journal != nil {
+		if entry, err := c.Journal.Get(hash); err == nil && entry != nil {
+			return entry.IdempotencyKey
+		}
+		return ""
+	}
+	for key, sent := range c.idempotentTxs {
+		if sent == hash {
+			return key
+		}
+	}
+	return ""
+}
+
+// builds and signs a transaction including the messages, returning its encoding
+func (c *Client) buildSignedTx(ctx context.Context, factory tx.Factory, msgs []sdk.Msg) ([]byte, error) {
+	unsignedTx, err := factory.BuildUnsignedTx(msgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build unsigned transaction %s", err)
+	}
+	return c.signTx(ctx, factory, unsignedTx)
+}
+
+// signs the body of the transaction again with the fee, gas and sequence of the factory, returning the encoding
+// of the new transaction. Only direct signing is supported, as other sign modes require the messages to be known
+func (c *Client) resignTx(ctx context.Context, factory tx.Factory, txBytes []byte, stuck *DecodedTx) (_ []byte, err error) {
+	_, span := c.startSpan(ctx, "compass.sign", AttributeSigner.String(c.FromAddress()))
+	defer func() { endSpan(span, err) }()
+
+	if mode := factory.SignMode(); mode != signing.SignMode_SIGN_MODE_UNSPECIFIED && mode != signing.SignMode_SIGN_MODE_DIRECT {
+		return nil, fmt.Errorf("transactions including messages unknown to the codec can only be signed in direct mode")
+	}
+	var raw txtypes.TxRaw
+	if err := raw.Unmarshal(txBytes); err != nil {
+		return nil, fmt.Errorf("failed to decode transaction %s", err)
+	}
+	record, err := c.Keyring.Key(c.cctx.GetFromName())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get key %s", err)
+	}
+	pubKey, err := record.GetPubKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get public key %s", err)
+	}
+	packedKey, err := codectypes.NewAnyWithValue(pubKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack public key %s", err)
+	}
+	authInfo := txtypes.AuthInfo{
+		SignerInfos: []*txtypes.SignerInfo{{
+			PublicKey: packedKey,
+			ModeInfo: &txtypes.ModeInfo{Sum: &txtypes.ModeInfo_Single_{
+				Single: &txtypes.ModeInfo_Single{Mode: signing.SignMode_SIGN_MODE_DIRECT},
+			}},
+			Sequence: factory.Sequence(),
+		}},
+		Fee: &txtypes.Fee{
+			Amount:   factory.Fees(),
+			GasLimit: factory.Gas(),
+			Payer:    stuck.FeePayer,
+			Granter:  stuck.FeeGranter,
+		},
+	}
+	authInfoBytes, err := authInfo.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode transaction %s", err)
+	}
+	signDoc := txtypes.SignDoc{
+		BodyBytes:     raw.BodyBytes,
+		AuthInfoBytes: authInfoBytes,
+		ChainId:       factory.ChainID(),
+		AccountNumber: factory.AccountNumber(),
+	}
+	signBytes, err := signDoc.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode sign doc %s", err)
+	}
+	signature, _, err := c.Keyring.Sign(c.cctx.GetFromName(), signBytes, signing.SignMode_SIGN_MODE_DIRECT)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction %s", err)
+	}
+	signed := txtypes.TxRaw{BodyBytes: raw.BodyBytes, AuthInfoBytes: authInfoBytes, Signatures: [][]byte{signature}}
+	return signed.Marshal()
+}
+
+// returns the messages of the decoded transaction, and whether all of them are known to the codec
+func decodedMsgs(decoded *DecodedTx) ([]sdk.Msg, bool) {
+	msgs := make([]sdk.Msg, 0, len(decoded.Msgs))
+	for _, msg := range decoded.Msgs {
+		if msg.Msg == nil {
+			return nil, false
+		}
+		msgs = append(msgs, msg.Msg)
+	}
+	return msgs, true
+}
+
+// returns the fee multiplied by the multiplier, rounding up
+func bumpFee(fee sdk.Coins, multiplier float64) (sdk.Coins, error) {
+	if multiplier <= 1 {
+		return nil, fmt.Errorf("fee multiplier must be greater than 1")
+	}
+	factor, err := sdkmath.LegacyNewDecFromStr(strconv.FormatFloat(multiplier, 'f', -1, 64))
+	if err != nil {
+		return nil, fmt.Errorf("invalid fee multiplier %s", err)
+	}
+	bumped := make(sdk.Coins, 0, len(fee))
+	for _, coin := range fee {
+		amount := sdkmath.LegacyNewDecFromInt(coin.Amount).Mul(factor).Ceil().TruncateInt()
+		bumped = append(bumped, sdk.NewCoin(coin.Denom, amount))
+	}
+	return bumped, nil
+}
@@ -0,0 +1,138 @@
+package compass_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	comettypes "github.com/cometbft/cometbft/types"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/require"
+	"github.com/teamscanworks/compass"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestMempool(t *testing.T) {
+	client, node := newJournaledClient(t)
+	ctx := context.Background()
+	signer := client.FromAddress()
+
+	// signs a send at the current sequence of the signer, as if it was sent by another process
+	factory := tx.Factory{}.
+		WithTxConfig(client.Codec.TxConfig).
+		WithKeybase(client.Keyring).
+		WithChainID("testing").
+		WithAccountNumber(1).
+		WithSequence(5).
+		WithGas(100_000).
+		WithFees("10stake").
+		WithMemo("stuck").
+		WithSignMode(signing.SignMode_SIGN_MODE_DIRECT)
+	send := &banktypes.MsgSend{FromAddress: signer, ToAddress: signer, Amount: sdk.NewCoins(sdk.NewInt64Coin("stake", 1))}
+	builder, err := factory.BuildUnsignedTx(send)
+	require.NoError(t, err)
+	require.NoError(t, tx.Sign(ctx, factory, "default", builder, true))
+	stuck, err := client.Codec.TxConfig.TxEncoder()(builder.GetTx())
+	require.NoError(t, err)
+	stuckHash := fmt.Sprintf("%X", comettypes.Tx(stuck).Hash())
+	node.mempool = []comettypes.Tx{comettypes.Tx("other"), stuck}
+
+	status, err := client.MempoolStatus(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, status.Txs)
+	require.Equal(t, int64(len(stuck)+len("other")), status.Bytes)
+	txs, err := client.UnconfirmedTxs(ctx, 0)
+	require.NoError(t, err)
+	require.Len(t, txs, 2)
+	require.Equal(t, stuckHash, txs[1].Hash)
+	pending, err := client.PendingTxs(ctx, stuckHash, "AA")
+	require.NoError(t, err)
+	require.Equal(t, []string{stuckHash}, pending)
+
+	_, err = client.ReplaceTx(ctx, stuckHash, sdk.NewCoins(sdk.NewInt64Coin("stake", 10)))
+	require.ErrorContains(t, err, "must exceed")
+	_, err = client.BumpFee(ctx, stuckHash, 1)
+	require.Error(t, err)
+
+	// the replacement pays more at the same sequence, and is included by the node
+	replacementHash, err := client.BumpFee(ctx, stuckHash, 1.15)
+	require.NoError(t, err)
+	require.NotEqual(t, stuckHash, replacementHash)
+	entry, err := client.Journal.Get(replacementHash)
+	require.NoError(t, err)
+	require.Equal(t, compass.TxStatusCommitted, entry.Status)
+	replacement, err := client.DecodeTx(entry.TxBytes)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), replacement.Signatures[0].Sequence)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 12)), replacement.Fee)
+	require.Equal(t, uint64(100_000), replacement.GasLimit)
+	require.Equal(t, "stuck", replacement.Memo)
+	require.Equal(t, send.Amount, replacement.Msgs[0].Msg.(*banktypes.MsgSend).Amount)
+
+	// the replaced transaction is evicted
+	node.mempool = nil
+	_, err = client.CancelTx(ctx, stuckHash, 2)
+	require.ErrorContains(t, err, "is not pending")
+
+	// a cancellation sends a single unit of the fee denom to the signer, and is included by the node
+	node.mempool = []comettypes.Tx{stuck}
+	cancelHash, err := client.CancelTx(ctx, stuckHash, 2)
+	require.NoError(t, err)
+	entry, err = client.Journal.Get(cancelHash)
+	require.NoError(t, err)
+	require.Equal(t, compass.TxStatusCommitted, entry.Status)
+	require.Equal(t, uint64(5), entry.Sequence)
+	cancel, err := client.DecodeTx(entry.TxBytes)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), cancel.Signatures[0].Sequence)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 20)), cancel.Fee)
+	require.Len(t, cancel.Msgs, 1)
+	cancelSend := cancel.Msgs[0].Msg.(*banktypes.MsgSend)
+	require.Equal(t, signer, cancelSend.FromAddress)
+	require.Equal(t, signer, cancelSend.ToAddress)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 1)), cancelSend.Amount)
+	included, err := client.RPC.Tx(ctx, comettypes.Tx(entry.TxBytes).Hash(), false)
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), included.TxResult.Code)
+}
+
+func TestReplaceUnknownMsgs(t *testing.T) {
+	var file protoreflect.FileDescriptor
+	client, node := newJournaledClient(t, func(srv *grpc.Server) { file = registerReflection(t, srv) })
+	ctx := context.Background()
+	record, err := client.Keyring.Key("default")
+	require.NoError(t, err)
+	pubKey, err := record.GetPubKey()
+	require.NoError(t, err)
+	stuck := unknownMsgTx(t, file, client.FromAddress(), pubKey)
+	stuckHash := fmt.Sprintf("%X", comettypes.Tx(stuck).Hash())
+	node.mempool = []comettypes.Tx{stuck}
+	decoded, err := client.DecodeTx(stuck)
+	require.NoError(t, err)
+
+	// the body of the pending transaction is signed again with the bumped fee
+	replacementHash, err := client.BumpFee(ctx, stuckHash, 2)
+	require.NoError(t, err)
+	entry, err := client.Journal.Get(replacementHash)
+	require.NoError(t, err)
+	require.Equal(t, compass.TxStatusCommitted, entry.Status)
+	require.Equal(t, []string{"/compass.test.v1.MsgCustom"}, entry.Msgs)
+	replacement, err := client.DecodeTx(entry.TxBytes)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 20)), replacement.Fee)
+	require.Equal(t, uint64(100_000), replacement.GasLimit)
+	require.Equal(t, uint64(5), replacement.Signatures[0].Sequence)
+	require.Equal(t, decoded.Msgs[0].JSON, replacement.Msgs[0].JSON)
+	var stuckRaw, raw txtypes.TxRaw
+	require.NoError(t, stuckRaw.Unmarshal(stuck))
+	require.NoError(t, raw.Unmarshal(entry.TxBytes))
+	require.Equal(t, stuckRaw.BodyBytes, raw.BodyBytes)
+	signBytes, err := (&txtypes.SignDoc{BodyBytes: raw.BodyBytes, AuthInfoBytes: raw.AuthInfoBytes, ChainId: "testing", AccountNumber: 1}).Marshal()
+	require.NoError(t, err)
+	require.True(t, pubKey.VerifySignature(signBytes, raw.Signatures[0]))
+}
@@ -0,0 +1,177 @@
+package compass
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"strconv"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// namespace of metrics when none is given
+const DefaultMetricsNamespace = "compass"
+
+// outcomes of transaction submissions
+const (
+	// the transaction was included in a block and executed successfully
+	TxOutcomeCommitted = "committed"
+	// the transaction was included in a block but its execution failed
+	TxOutcomeFailed = "failed"
+	// the transaction was rejected by the node before inclusion (ie: by `CheckTx`)
+	TxOutcomeRejected = "rejected"
+	// the transaction could not be broadcast or its inclusion could not be confirmed
+	TxOutcomeError = "error"
+)
+
+// Prometheus collectors recording the transactions, queries and connections of a client. Set
+// `ClientConfig.Metrics` to enable them, a nil collector records nothing
+type Metrics struct {
+	txs                 *prometheus.CounterVec
+	confirmationLatency prometheus.Histogram
+	gasUsed             prometheus.Histogram
+	gasWanted           prometheus.Histogram
+	fees                *prometheus.CounterVec
+	callLatency         *prometheus.HistogramVec
+	sequenceMismatches  prometheus.Counter
+	failovers           *prometheus.CounterVec
+}
+
+// Returns metrics registered against the registerer, such that they can be exposed alongside those of the
+// host application. Metrics are prefixed by the namespace, defaulting to `compass`, and labels common to
+// all metrics (ie: a chain ID when running multiple clients) can be added with `prometheus.WrapRegistererWith`
+func NewMetrics(registerer prometheus.Registerer, namespace string) (*Metrics, error) {
+	if namespace == "" {
+		namespace = DefaultMetricsNamespace
+	}
+	gasBuckets := prometheus.ExponentialBuckets(50_000, 2, 10)
+	m := &Metrics{
+		txs: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "tx_submissions_total",
+			Help:      "Transactions submitted, by outcome and abci code.",
+		}, []string{"outcome", "code"}),
+		confirmationLatency: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "tx_confirmation_seconds",
+			Help:      "Time between broadcasting a transaction and confirming its inclusion.",
+			Buckets:   prometheus.ExponentialBuckets(0.5, 2, 8),
+		}),
+		gasUsed: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "tx_gas_used",
+			Help:      "Gas used by included transactions.",
+			Buckets:   gasBuckets,
+		}),
+		gasWanted: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "tx_gas_wanted",
+			Help:      "Gas limit of included transactions.",
+			Buckets:   gasBuckets,
+		}),
+		fees: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "tx_fees_total",
+			Help:      "Fees paid by included transactions, by denom.",
+		}, []string{"denom"}),
+		callLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "call_duration_seconds",
+			Help:      "Latency of gRPC and RPC calls, by transport, method and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"transport", "method", "status"}),
+		sequenceMismatches: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "sequence_mismatches_total",
+			Help:      "Transactions rejected due to an account sequence mismatch.",
+		}),
+		failovers: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "endpoint_failovers_total",
+			Help:      "Reconnections which failed over to an alternate endpoint, by transport.",
+		}, []string{"transport"}),
+	}
+	for _, collector := range []prometheus.Collector{
+		m.txs, m.confirmationLatency, m.gasUsed, m.gasWanted, m.fees, m.callLatency, m.sequenceMismatches, m.failovers,
+	} {
+		if err := registerer.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// records the outcome of a transaction submission
+func (m *Metrics) observeTx(outcome string, codespace string, code uint32) {
+	if m == nil {
+		return
+	}
+	codeLabel := ""
+	if outcome != TxOutcomeError {
+		codeLabel = strconv.FormatUint(uint64(code), 10)
+	}
+	m.txs.WithLabelValues(outcome, codeLabel).Inc()
+	if codespace == sdkerrors.ErrWrongSequence.Codespace() && code == sdkerrors.ErrWrongSequence.ABCICode() {
+		m.sequenceMismatches.Inc()
+	}
+}
+
+// records the inclusion of a transaction, and the fees it paid
+func (m *Metrics) observeInclusion(latency time.Duration, gasUsed, gasWanted int64, fee sdk.Coins) {
+	if m == nil {
+		return
+	}
+	m.confirmationLatency.Observe(latency.Seconds())
+	m.gasUsed.Observe(float64(gasUsed))
+	m.gasWanted.Observe(float64(gasWanted))
+	for _, coin := range fee {
+		amount, _ := new(big.Float).SetInt(coin.Amount.BigInt()).Float64()
+		m.fees.WithLabelValues(coin.Denom).Add(amount)
+	}
+}
+
+// records the latency of a gRPC or RPC call
+func (m *Metrics) observeCall(transport, method string, latency time.Duration, err error) {
+	if m == nil {
+		return
+	}
+	status := "ok"
+	if err != nil {
+		status = "error"
+	}
+	m.callLatency.WithLabelValues(transport, method, status).Observe(latency.Seconds())
+}
+
+// records a reconnection which used an alternate endpoint
+func (m *Metrics) observeFailover(transport string) {
+	if m == nil {
+		return
+	}
+	m.failovers.WithLabelValues(transport).Inc()
+}
+
+// returns the json-rpc method of a request sent by the rpc client, without consuming its body
+func rpcMethod(req *http.Request) string {
+	if req.Method == http.MethodGet {
+		return req.URL.Path
+	}
+	if req.GetBody == nil {
+		return "unknown"
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return "unknown"
+	}
+	defer body.Close()
+	var call struct {
+		Method string `json:"method"`
+	}
+	if err := json.NewDecoder(body).Decode(&call); err != nil || call.Method == "" {
+		// batched requests are encoded as an array
+		return "batch"
+	}
+	return call.Method
+}
@@ -0,0 +1,86 @@
+package compass_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+	"github.com/teamscanworks/compass"
+	"go.uber.org/zap"
+)
+
+// returns the metrics of the family with the given name whose labels include the given labels
+func findMetrics(t *testing.T, registry *prometheus.Registry, name string, labels map[string]string) []*dto.Metric {
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	var found []*dto.Metric
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+	metrics:
+		for _, metric := range family.GetMetric() {
+			values := make(map[string]string)
+			for _, label := range metric.GetLabel() {
+				values[label.GetName()] = label.GetValue()
+			}
+			for name, value := range labels {
+				if values[name] != value {
+					continue metrics
+				}
+			}
+			found = append(found, metric)
+		}
+	}
+	return found
+}
+
+func TestMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	metrics, err := compass.NewMetrics(registry, "")
+	require.NoError(t, err)
+	// collectors clash when registered twice against the same registry
+	_, err = compass.NewMetrics(registry, "")
+	require.Error(t, err)
+	_, err = compass.NewMetrics(registry, "other")
+	require.NoError(t, err)
+
+	logger, err := zap.NewDevelopment()
+	require.NoError(t, err)
+	node := newFakeNode(t, "testing")
+	cfg := newTestConfig("http://127.0.0.1:1", "127.0.0.1:1")
+	cfg.RPCAddrs = []string{node.srv.URL}
+	cfg.GRPCAddrs = []string{newNodeInfoServer(t, "testing")}
+	cfg.Metrics = metrics
+	client, err := compass.NewClient(logger, cfg, []keyring.Option{compass.DefaultSignatureOptions()})
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close(context.Background()) })
+	ctx := context.Background()
+
+	require.NoError(t, client.Reconnect(ctx))
+	for _, transport := range []string{"rpc", "grpc"} {
+		failovers := findMetrics(t, registry, "compass_endpoint_failovers_total", map[string]string{"transport": transport})
+		require.Len(t, failovers, 1)
+		require.Equal(t, float64(1), failovers[0].GetCounter().GetValue())
+	}
+	grpcCalls := findMetrics(t, registry, "compass_call_duration_seconds", map[string]string{
+		"transport": "grpc",
+		"method":    "/cosmos.base.tendermint.v1beta1.Service/GetNodeInfo",
+		"status":    "ok",
+	})
+	require.Len(t, grpcCalls, 1)
+	require.Equal(t, uint64(1), grpcCalls[0].GetHistogram().GetSampleCount())
+
+	_, err = client.NodeHealth(ctx)
+	require.NoError(t, err)
+	for _, method := range []string{"status", "abci_info", "net_info"} {
+		require.Len(t, findMetrics(t, registry, "compass_call_duration_seconds", map[string]string{
+			"transport": "rpc",
+			"method":    method,
+			"status":    "ok",
+		}), 1, method)
+	}
+}
@@ -0,0 +1,239 @@
+package compass
+
+import (
+	"context"
+	"fmt"
+
+	sdkmath "cosmossdk.io/math"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+
+	"github.com/teamscanworks/compass/types/osmosis/poolmanager"
+	"github.com/teamscanworks/compass/types/osmosis/tokenfactory"
+)
+
+// The estimated output of swapping along a route
+type SwapEstimate struct {
+	Routes         []poolmanager.SwapAmountInRoute
+	TokenOutAmount sdkmath.Int
+}
+
+// Returns the amount of the final output token received when swapping `tokenIn` along the routes
+func (c *Client) EstimateSwapExactAmountIn(ctx context.Context, tokenIn sdk.Coin, routes []poolmanager.SwapAmountInRoute) (sdkmath.Int, error) {
+	if len(routes) == 0 {
+		return sdkmath.Int{}, fmt.Errorf("at least one route is required")
+	}
+	res, err := poolmanager.NewQueryClient(c.grpcConn()).EstimateSwapExactAmountIn(ctx, &poolmanager.EstimateSwapExactAmountInRequest{
+		PoolId:  routes[0].PoolId,
+		TokenIn: tokenIn.String(),
+		Routes:  routes,
+	})
+	if err != nil {
+		return sdkmath.Int{}, fmt.Errorf("failed to estimate swap %s", err)
+	}
+	return res.TokenOutAmount, nil
+}
+
+// Returns the amount of the first input token required to receive `tokenOut` when swapping along the routes
+func (c *Client) EstimateSwapExactAmountOut(ctx context.Context, routes []poolmanager.SwapAmountOutRoute, tokenOut sdk.Coin) (sdkmath.Int, error) {
+	if len(routes) == 0 {
+		return sdkmath.Int{}, fmt.Errorf("at least one route is required")
+	}
+	res, err := poolmanager.NewQueryClient(c.grpcConn()).EstimateSwapExactAmountOut(ctx, &poolmanager.EstimateSwapExactAmountOutRequest{
+		PoolId:   routes[0].PoolId,
+		Routes:   routes,
+		TokenOut: tokenOut.String(),
+	})
+	if err != nil {
+		return sdkmath.Int{}, fmt.Errorf("failed to estimate swap %s", err)
+	}
+	return res.TokenInAmount, nil
+}
+
+// Estimates swapping `tokenIn` along each of the candidate routes, returning the route with the greatest
+// output. Routes which can not be estimated (ie: due to insufficient liquidity) are skipped
+func (c *Client) BestSwapRoute(ctx context.Context, tokenIn sdk.Coin, candidates ...[]poolmanager.SwapAmountInRoute) (*SwapEstimate, error) {
+	var (
+		best    *SwapEstimate
+		lastErr error
+	)
+	for _, routes := range candidates {
+		amount, err := c.EstimateSwapExactAmountIn(ctx, tokenIn, routes)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if best == nil || amount.GT(best.TokenOutAmount) {
+			best = &SwapEstimate{Routes: routes, TokenOutAmount: amount}
+		}
+	}
+	if best == nil {
+		if lastErr == nil {
+			lastErr = fmt.Errorf("no candidate routes")
+		}
+		return nil, fmt.Errorf("failed to estimate any route %w", lastErr)
+	}
+	return best, nil
+}
+
+// Swaps `tokenIn` along the routes, failing if less than `tokenOutMinAmount` of the final output token would be
+// received. Returns the amount of the output token received
+func (c *Client) SwapExactAmountIn(
+	ctx context.Context,
+	tokenIn sdk.Coin,
+	routes []poolmanager.SwapAmountInRoute,
+	tokenOutMinAmount sdkmath.Int,
+) (sdkmath.Int, error) {
+	msg := &poolmanager.MsgSwapExactAmountIn{
+		Sender:            c.FromAddress(),
+		Routes:            routes,
+		TokenIn:           tokenIn,
+		TokenOutMinAmount: tokenOutMinAmount,
+	}
+	resp := &poolmanager.MsgSwapExactAmountInResponse{}
+	if err := c.sendMsg(ctx, msg, resp); err != nil {
+		return sdkmath.Int{}, err
+	}
+	return resp.TokenOutAmount, nil
+}
+
+// Returns the pool with the given id. Requires the osmosis codec bundle to be enabled
+func (c *Client) Pool(ctx context.Context, poolID uint64) (poolmanager.PoolI, error) {
+	res, err := poolmanager.NewQueryClient(c.grpcConn()).Pool(ctx, &poolmanager.PoolRequest{PoolId: poolID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pool %s", err)
+	}
+	return c.unpackPool(res.Pool)
+}
+
+// Returns all pools sorted by id. Requires the osmosis codec bundle to be enabled
+func (c *Client) AllPools(ctx context.Context) ([]poolmanager.PoolI, error) {
+	res, err := poolmanager.NewQueryClient(c.grpcConn()).AllPools(ctx, &poolmanager.AllPoolsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pools %s", err)
+	}
+	pools := make([]poolmanager.PoolI, 0, len(res.Pools))
+	for _, packed := range res.Pools {
+		pool, err := c.unpackPool(packed)
+		if err != nil {
+			return nil, err
+		}
+		pools = append(pools, pool)
+	}
+	return pools, nil
+}
+
+// Returns the number of pools
+func (c *Client) NumPools(ctx context.Context) (uint64, error) {
+	res, err := poolmanager.NewQueryClient(c.grpcConn()).NumPools(ctx, &poolmanager.NumPoolsRequest{})
+	if err != nil {
+		return 0, fmt.Errorf("failed to query number of pools %s", err)
+	}
+	return res.NumPools, nil
+}
+
+// Returns the spot price of the base denomination in terms of the quote denomination
+func (c *Client) SpotPrice(ctx context.Context, poolID uint64, baseDenom, quoteDenom string) (sdkmath.LegacyDec, error) {
+	res, err := poolmanager.NewQueryClient(c.grpcConn()).SpotPrice(ctx, &poolmanager.SpotPriceRequest{
+		PoolId:          poolID,
+		BaseAssetDenom:  baseDenom,
+		QuoteAssetDenom: quoteDenom,
+	})
+	if err != nil {
+		return sdkmath.LegacyDec{}, fmt.Errorf("failed to query spot price %s", err)
+	}
+	price, err := sdkmath.LegacyNewDecFromStr(res.SpotPrice)
+	if err != nil {
+		return sdkmath.LegacyDec{}, fmt.Errorf("invalid spot price %s", err)
+	}
+	return price, nil
+}
+
+// Returns the total liquidity held by the pool
+func (c *Client) PoolLiquidity(ctx context.Context, poolID uint64) (sdk.Coins, error) {
+	res, err := poolmanager.NewQueryClient(c.grpcConn()).TotalPoolLiquidity(ctx, &poolmanager.TotalPoolLiquidityRequest{PoolId: poolID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pool liquidity %s", err)
+	}
+	return res.Liquidity, nil
+}
+
+// Creates a tokenfactory denomination administered by the sender, returning the full denomination
+func (c *Client) CreateDenom(ctx context.Context, subdenom string) (string, error) {
+	msg := &tokenfactory.MsgCreateDenom{
+		Sender:   c.FromAddress(),
+		Subdenom: subdenom,
+	}
+	resp := &tokenfactory.MsgCreateDenomResponse{}
+	if err := c.sendMsg(ctx, msg, resp); err != nil {
+		return "", err
+	}
+	return resp.NewTokenDenom, nil
+}
+
+// Mints tokenfactory tokens to the recipient, which defaults to the sender when empty
+func (c *Client) MintDenom(ctx context.Context, amount sdk.Coin, recipient string) error {
+	msg := &tokenfactory.MsgMint{
+		Sender:        c.FromAddress(),
+		Amount:        amount,
+		MintToAddress: recipient,
+	}
+	return c.sendMsg(ctx, msg, &tokenfactory.MsgMintResponse{})
+}
+
+// Burns tokenfactory tokens held by the given address, which defaults to the sender when empty
+func (c *Client) BurnDenom(ctx context.Context, amount sdk.Coin, from string) error {
+	msg := &tokenfactory.MsgBurn{
+		Sender:          c.FromAddress(),
+		Amount:          amount,
+		BurnFromAddress: from,
+	}
+	return c.sendMsg(ctx, msg, &tokenfactory.MsgBurnResponse{})
+}
+
+// Transfers administration of a tokenfactory denomination to the new admin
+func (c *Client) ChangeDenomAdmin(ctx context.Context, denom, newAdmin string) error {
+	msg := &tokenfactory.MsgChangeAdmin{
+		Sender:   c.FromAddress(),
+		Denom:    denom,
+		NewAdmin: newAdmin,
+	}
+	return c.sendMsg(ctx, msg, &tokenfactory.MsgChangeAdminResponse{})
+}
+
+// Sets the bank metadata of a tokenfactory denomination
+func (c *Client) SetDenomMetadata(ctx context.Context, metadata banktypes.Metadata) error {
+	msg := &tokenfactory.MsgSetDenomMetadata{
+		Sender:   c.FromAddress(),
+		Metadata: metadata,
+	}
+	return c.sendMsg(ctx, msg, &tokenfactory.MsgSetDenomMetadataResponse{})
+}
+
+// Returns the tokenfactory denominations created by the given address
+func (c *Client) DenomsFromCreator(ctx context.Context, creator string) ([]string, error) {
+	res, err := tokenfactory.NewQueryClient(c.grpcConn()).DenomsFromCreator(ctx, &tokenfactory.QueryDenomsFromCreatorRequest{Creator: creator})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query denoms %s", err)
+	}
+	return res.Denoms, nil
+}
+
+// Returns the admin of a tokenfactory denomination, which is empty if the denomination has no admin
+func (c *Client) DenomAdmin(ctx context.Context, denom string) (string, error) {
+	res, err := tokenfactory.NewQueryClient(c.grpcConn()).DenomAuthorityMetadata(ctx, &tokenfactory.QueryDenomAuthorityMetadataRequest{Denom: denom})
+	if err != nil {
+		return "", fmt.Errorf("failed to query denom admin %s", err)
+	}
+	return res.AuthorityMetadata.Admin, nil
+}
+
+// unpacks a pool returned by the poolmanager
+func (c *Client) unpackPool(packed *codectypes.Any) (poolmanager.PoolI, error) {
+	var pool poolmanager.PoolI
+	if err := c.Codec.InterfaceRegistry.UnpackAny(packed, &pool); err != nil {
+		return nil, fmt.Errorf("failed to decode pool, is the osmosis codec bundle enabled? %s", err)
+	}
+	return pool, nil
+}
@@ -0,0 +1,187 @@
+package compass_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	govv1beta1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1beta1"
+	"github.com/stretchr/testify/require"
+	"github.com/teamscanworks/compass"
+	"github.com/teamscanworks/compass/types/osmosis"
+	"github.com/teamscanworks/compass/types/osmosis/gamm"
+	"github.com/teamscanworks/compass/types/osmosis/lockup"
+	"github.com/teamscanworks/compass/types/osmosis/poolmanager"
+	"github.com/teamscanworks/compass/types/osmosis/superfluid"
+	"github.com/teamscanworks/compass/types/osmosis/tokenfactory"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// estimates swaps with a fixed rate per pool, failing for pools without liquidity
+type poolmanagerService struct {
+	poolmanager.UnimplementedQueryServer
+	rates map[uint64]int64
+}
+
+func (s *poolmanagerService) EstimateSwapExactAmountIn(_ context.Context, req *poolmanager.EstimateSwapExactAmountInRequest) (*poolmanager.EstimateSwapExactAmountInResponse, error) {
+	tokenIn, err := sdk.ParseCoinNormalized(req.TokenIn)
+	if err != nil {
+		return nil, err
+	}
+	amount := tokenIn.Amount
+	for _, route := range req.Routes {
+		rate, ok := s.rates[route.PoolId]
+		if !ok {
+			return nil, fmt.Errorf("pool %d has no liquidity", route.PoolId)
+		}
+		amount = amount.MulRaw(rate)
+	}
+	return &poolmanager.EstimateSwapExactAmountInResponse{TokenOutAmount: amount}, nil
+}
+
+func (s *poolmanagerService) EstimateSwapExactAmountOut(_ context.Context, req *poolmanager.EstimateSwapExactAmountOutRequest) (*poolmanager.EstimateSwapExactAmountOutResponse, error) {
+	tokenOut, err := sdk.ParseCoinNormalized(req.TokenOut)
+	if err != nil {
+		return nil, err
+	}
+	amount := tokenOut.Amount
+	for _, route := range req.Routes {
+		rate, ok := s.rates[route.PoolId]
+		if !ok {
+			return nil, fmt.Errorf("pool %d has no liquidity", route.PoolId)
+		}
+		amount = amount.QuoRaw(rate)
+	}
+	return &poolmanager.EstimateSwapExactAmountOutResponse{TokenInAmount: amount}, nil
+}
+
+func TestMakeCodecOsmosis(t *testing.T) {
+	cfg := compass.GetOsmosisConfig("home", false)
+	require.Contains(t, cfg.ExtraCodecs, "osmosis")
+
+	msgURL := sdk.MsgTypeURL(&poolmanager.MsgSwapExactAmountIn{})
+	_, err := compass.MakeCodec(compass.ModuleBasics, nil).InterfaceRegistry.Resolve(msgURL)
+	require.Error(t, err)
+	cdc := compass.MakeCodec(compass.ModuleBasics, cfg.ExtraCodecs)
+	for _, msg := range []sdk.Msg{
+		&poolmanager.MsgSwapExactAmountIn{},
+		&gamm.MsgJoinPool{},
+		&lockup.MsgLockTokens{},
+		&tokenfactory.MsgCreateDenom{},
+	} {
+		resolved, err := cdc.InterfaceRegistry.Resolve(sdk.MsgTypeURL(msg))
+		require.NoError(t, err)
+		require.IsType(t, msg, resolved)
+	}
+
+	sender := sdk.AccAddress([]byte("sender_address______"))
+	signingCdc := newSigningCodec(t, osmosis.AppModuleBasic{}.RegisterInterfaces)
+	swap := &poolmanager.MsgSwapExactAmountIn{
+		Sender:            sdk.MustBech32ifyAddressBytes("cosmos", sender),
+		Routes:            []poolmanager.SwapAmountInRoute{{PoolId: 1, TokenOutDenom: "uion"}},
+		TokenIn:           sdk.NewInt64Coin("uosmo", 100),
+		TokenOutMinAmount: sdkmath.NewInt(1),
+	}
+	signers, _, err := signingCdc.GetMsgV1Signers(swap)
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{sender}, signers)
+	lock := &lockup.MsgLockTokens{
+		Owner:    sdk.MustBech32ifyAddressBytes("cosmos", sender),
+		Duration: 24 * time.Hour,
+		Coins:    sdk.NewCoins(sdk.NewInt64Coin("gamm/pool/1", 100)),
+	}
+	signers, _, err = signingCdc.GetMsgV1Signers(lock)
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{sender}, signers)
+
+	packed, err := codectypes.NewAnyWithValue(swap)
+	require.NoError(t, err)
+	var unpacked sdk.Msg
+	require.NoError(t, cdc.InterfaceRegistry.UnpackAny(packed, &unpacked))
+	require.Equal(t, swap, unpacked)
+
+	proposal := &superfluid.SetSuperfluidAssetsProposal{Title: "title", Description: "description"}
+	packed, err = codectypes.NewAnyWithValue(proposal)
+	require.NoError(t, err)
+	var content govv1beta1.Content
+	require.NoError(t, cdc.InterfaceRegistry.UnpackAny(packed, &content))
+	require.Equal(t, "title", content.GetTitle())
+}
+
+func TestUnpackOsmosisPool(t *testing.T) {
+	cdc := compass.MakeCodec(compass.ModuleBasics, []string{"osmosis"})
+	pool := &gamm.Pool{
+		Id:          1,
+		TotalShares: sdk.NewInt64Coin("gamm/pool/1", 100),
+		TotalWeight: sdkmath.NewInt(2),
+	}
+	packed, err := codectypes.NewAnyWithValue(pool)
+	require.NoError(t, err)
+	bz, err := cdc.Marshaler.Marshal(packed)
+	require.NoError(t, err)
+	decoded := &codectypes.Any{}
+	require.NoError(t, cdc.Marshaler.Unmarshal(bz, decoded))
+	var unpacked poolmanager.PoolI
+	require.NoError(t, cdc.InterfaceRegistry.UnpackAny(decoded, &unpacked))
+	require.Equal(t, uint64(1), unpacked.GetId())
+	require.IsType(t, &gamm.Pool{}, unpacked)
+}
+
+func TestTokenfactoryDenom(t *testing.T) {
+	require.Equal(t, "factory/osmo1creator/utoken", tokenfactory.Denom("osmo1creator", "utoken"))
+}
+
+func TestEstimateSwap(t *testing.T) {
+	logger, err := zap.NewDevelopment()
+	require.NoError(t, err)
+	ctx := context.Background()
+	node := newFakeNode(t, "testing")
+	cfg := newTestConfig(node.srv.URL, newNodeInfoServer(t, "testing", func(srv *grpc.Server) {
+		poolmanager.RegisterQueryServer(srv, &poolmanagerService{rates: map[uint64]int64{1: 2, 2: 3, 3: 4}})
+	}))
+	cfg.ExtraCodecs = []string{"osmosis"}
+	client, err := compass.NewClient(logger, cfg, []keyring.Option{compass.DefaultSignatureOptions()})
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close(context.Background()) })
+
+	tokenIn := sdk.NewInt64Coin("uosmo", 100)
+	amount, err := client.EstimateSwapExactAmountIn(ctx, tokenIn, []poolmanager.SwapAmountInRoute{
+		{PoolId: 1, TokenOutDenom: "uatom"},
+		{PoolId: 2, TokenOutDenom: "uion"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, sdkmath.NewInt(600), amount)
+	_, err = client.EstimateSwapExactAmountIn(ctx, tokenIn, nil)
+	require.Error(t, err)
+
+	amount, err = client.EstimateSwapExactAmountOut(ctx, []poolmanager.SwapAmountOutRoute{
+		{PoolId: 1, TokenInDenom: "uosmo"},
+	}, sdk.NewInt64Coin("uatom", 200))
+	require.NoError(t, err)
+	require.Equal(t, sdkmath.NewInt(100), amount)
+	_, err = client.EstimateSwapExactAmountOut(ctx, []poolmanager.SwapAmountOutRoute{{PoolId: 9, TokenInDenom: "uosmo"}}, sdk.NewInt64Coin("uatom", 200))
+	require.ErrorContains(t, err, "pool 9 has no liquidity")
+
+	// routes which can't be estimated are skipped
+	direct := []poolmanager.SwapAmountInRoute{{PoolId: 3, TokenOutDenom: "uion"}}
+	best, err := client.BestSwapRoute(ctx, tokenIn,
+		[]poolmanager.SwapAmountInRoute{{PoolId: 1, TokenOutDenom: "uatom"}, {PoolId: 2, TokenOutDenom: "uion"}},
+		[]poolmanager.SwapAmountInRoute{{PoolId: 9, TokenOutDenom: "uion"}},
+		direct,
+	)
+	require.NoError(t, err)
+	require.Equal(t, sdkmath.NewInt(600), best.TokenOutAmount)
+	require.Len(t, best.Routes, 2)
+	best, err = client.BestSwapRoute(ctx, tokenIn, direct)
+	require.NoError(t, err)
+	require.Equal(t, direct, best.Routes)
+	require.Equal(t, sdkmath.NewInt(400), best.TokenOutAmount)
+	_, err = client.BestSwapRoute(ctx, tokenIn, []poolmanager.SwapAmountInRoute{{PoolId: 9, TokenOutDenom: "uion"}})
+	require.ErrorContains(t, err, "pool 9 has no liquidity")
+}
@@ -0,0 +1,105 @@
+package compass
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/mattn/go-isatty"
+)
+
+// PassphraseProvider supplies the passphrase used to unlock encrypted keyring backends
+// such as `file` and `os`, removing the need for an interactive terminal prompt
+type PassphraseProvider interface {
+	Passphrase() (string, error)
+}
+
+// PassphraseFunc adapts a function to the PassphraseProvider interface, and can be used
+// to hook up callbacks or secret managers
+type PassphraseFunc func() (string, error)
+
+// Returns the passphrase by invoking the underlying function
+func (fn PassphraseFunc) Passphrase() (string, error) {
+	return fn()
+}
+
+// Returns a provider which always supplies the given passphrase
+func StaticPassphrase(passphrase string) PassphraseProvider {
+	return PassphraseFunc(func() (string, error) {
+		return passphrase, nil
+	})
+}
+
+// Returns a provider which reads the passphrase from the given environment variable
+func EnvPassphrase(name string) PassphraseProvider {
+	return PassphraseFunc(func() (string, error) {
+		passphrase, ok := os.LookupEnv(name)
+		if !ok || passphrase == "" {
+			return "", fmt.Errorf("passphrase environment variable %s is not set", name)
+		}
+		return passphrase, nil
+	})
+}
+
+// Returns a provider which reads the passphrase from the given file, ignoring any
+// trailing newline characters
+func FilePassphrase(path string) PassphraseProvider {
+	return PassphraseFunc(func() (string, error) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read passphrase file %s", err)
+		}
+		passphrase := strings.TrimRight(string(data), "\r\n")
+		if passphrase == "" {
+			return "", fmt.Errorf("passphrase file %s is empty", path)
+		}
+		return passphrase, nil
+	})
+}
+
+// Returns an io.Reader which endlessly yields the passphrase from the given provider
+// as newline terminated input. This is suitable for usage as the `userInput` of the
+// cosmos-sdk keyring, which may prompt for the passphrase more than once (ie: when
+// confirming the passphrase of a newly created keyring).
+//
+// NOTE: the cosmos-sdk keyring only consumes this input when stdin is not a terminal,
+// which is always the case for daemons and containers. The client therefore refuses to
+// initialize a keyring with a passphrase provider when stdin is a terminal.
+func NewPassphraseReader(provider PassphraseProvider) io.Reader {
+	return &passphraseReader{provider: provider}
+}
+
+// reports whether stdin is a terminal, using the same check as the cosmos-sdk keyring prompt
+var stdinIsTerminal = func() bool {
+	return isatty.IsTerminal(os.Stdin.Fd()) || isatty.IsCygwinTerminal(os.Stdin.Fd())
+}
+
+// io.Reader implementation which lazily loads the passphrase from a provider
+type passphraseReader struct {
+	provider PassphraseProvider
+	once     sync.Once
+	line     []byte
+	err      error
+	offset   int
+}
+
+func (pr *passphraseReader) Read(p []byte) (int, error) {
+	pr.once.Do(func() {
+		passphrase, err := pr.provider.Passphrase()
+		if err != nil {
+			pr.err = fmt.Errorf("failed to load keyring passphrase %s", err)
+			return
+		}
+		pr.line = []byte(passphrase + "\n")
+	})
+	if pr.err != nil {
+		return 0, pr.err
+	}
+	// never read past the end of the current line, as the keyring wraps this reader
+	// in a new buffered reader for every prompt, discarding anything left buffered
+	n := copy(p, pr.line[pr.offset:])
+	pr.offset = (pr.offset + n) % len(pr.line)
+	return n, nil
+}
@@ -0,0 +1,90 @@
+package compass_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/stretchr/testify/require"
+	"github.com/teamscanworks/compass"
+	"go.uber.org/zap"
+)
+
+func TestPassphraseProviders(t *testing.T) {
+	t.Run("Env", func(t *testing.T) {
+		t.Setenv("COMPASS_TEST_PASSPHRASE", "supersecret")
+		pass, err := compass.EnvPassphrase("COMPASS_TEST_PASSPHRASE").Passphrase()
+		require.NoError(t, err)
+		require.Equal(t, "supersecret", pass)
+		_, err = compass.EnvPassphrase("COMPASS_TEST_PASSPHRASE_UNSET").Passphrase()
+		require.Error(t, err)
+	})
+	t.Run("File", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "passphrase")
+		require.NoError(t, os.WriteFile(path, []byte("supersecret\n"), 0o600))
+		pass, err := compass.FilePassphrase(path).Passphrase()
+		require.NoError(t, err)
+		require.Equal(t, "supersecret", pass)
+		_, err = compass.FilePassphrase(filepath.Join(t.TempDir(), "missing")).Passphrase()
+		require.Error(t, err)
+	})
+	t.Run("Reader", func(t *testing.T) {
+		reader := compass.NewPassphraseReader(compass.StaticPassphrase("abc"))
+		buf := make([]byte, 16)
+		for i := 0; i < 2; i++ {
+			n, err := reader.Read(buf)
+			require.NoError(t, err)
+			require.Equal(t, "abc\n", string(buf[:n]))
+		}
+	})
+}
+
+func TestFileKeyringPassphrase(t *testing.T) {
+	logger, err := zap.NewDevelopment()
+	require.NoError(t, err)
+	keyDir := t.TempDir()
+	newClient := func(provider compass.PassphraseProvider) *compass.Client {
+		cfg := compass.GetSimdConfig()
+		cfg.KeyringBackend = keyring.BackendFile
+		cfg.KeyDirectory = keyDir
+		cfg.Passphrase = provider
+		client, err := compass.NewClient(logger, cfg, []keyring.Option{compass.DefaultSignatureOptions()})
+		require.NoError(t, err)
+		t.Cleanup(func() { client.Close(context.Background()) })
+		return client
+	}
+
+	client := newClient(compass.StaticPassphrase("supersecret"))
+	ko, err := client.AddKey("default", 118)
+	require.NoError(t, err)
+
+	// re-opening the keyring with the same passphrase exposes the key
+	client = newClient(compass.StaticPassphrase("supersecret"))
+	address, err := client.ShowAddress("default")
+	require.NoError(t, err)
+	require.Equal(t, ko.Address, address)
+
+	// an incorrect passphrase is unable to unlock the keyring
+	client = newClient(compass.StaticPassphrase("incorrect"))
+	_, err = client.ShowAddress("default")
+	require.Error(t, err)
+}
+
+func TestPassphraseTerminal(t *testing.T) {
+	t.Cleanup(compass.SetStdinIsTerminal(true))
+	cfg := compass.GetSimdConfig()
+	cfg.KeyringBackend = keyring.BackendFile
+	cfg.KeyDirectory = t.TempDir()
+	cfg.Passphrase = compass.StaticPassphrase("supersecret")
+
+	// the keyring would prompt the terminal, ignoring the provider
+	_, err := compass.NewClient(zap.NewNop(), cfg, []keyring.Option{compass.DefaultSignatureOptions()})
+	require.ErrorContains(t, err, "stdin is a terminal")
+
+	cfg.KeyringBackend = keyring.BackendTest
+	client, err := compass.NewClient(zap.NewNop(), cfg, []keyring.Option{compass.DefaultSignatureOptions()})
+	require.NoError(t, err)
+	client.Close(context.Background())
+}
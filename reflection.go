@@ -0,0 +1,388 @@
+package compass
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	reflectionv2 "github.com/cosmos/cosmos-sdk/server/grpc/reflection/v2alpha1"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	gogoproto "github.com/cosmos/gogoproto/proto"
+	"google.golang.org/grpc"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// Protobuf types of a chain which are discovered through the cosmos reflection service and gRPC
+// server reflection. Types which are not registered against the codec of the client are resolved
+// through the node, allowing messages and accounts of unknown app chains to be decoded into json
+// rather than failing to unpack.
+//
+// Descriptors are fetched lazily as unknown types are encountered, or eagerly with `Discover`
+type DynamicTypes struct {
+	conn grpc.ClientConnInterface
+
+	mu sync.RWMutex
+	// descriptors fetched from the node, which excludes those already known to compass
+	files      *protoregistry.Files
+	msgs       []string
+	interfaces map[string][]string
+}
+
+// Returns dynamic types resolved through the node at the other end of the connection
+func NewDynamicTypes(conn grpc.ClientConnInterface) *DynamicTypes {
+	return &DynamicTypes{
+		conn:       conn,
+		files:      new(protoregistry.Files),
+		interfaces: make(map[string][]string),
+	}
+}
+
+// Queries the cosmos reflection service for the messages and interface implementations supported by
+// the chain, and fetches the descriptors of all of them
+func (d *DynamicTypes) Discover(ctx context.Context) error {
+	client := reflectionv2.NewReflectionServiceClient(d.conn)
+	txRes, err := client.GetTxDescriptor(ctx, &reflectionv2.GetTxDescriptorRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to query tx descriptor %s", err)
+	}
+	codecRes, err := client.GetCodecDescriptor(ctx, &reflectionv2.GetCodecDescriptorRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to query codec descriptor %s", err)
+	}
+	var (
+		msgs       []string
+		interfaces = make(map[string][]string)
+		names      []string
+	)
+	for _, msg := range txRes.Tx.GetMsgs() {
+		msgs = append(msgs, msg.MsgTypeUrl)
+		names = append(names, typeNameFromURL(msg.MsgTypeUrl))
+	}
+	for _, iface := range codecRes.Codec.GetInterfaces() {
+		for _, impl := range iface.InterfaceImplementers {
+			interfaces[iface.Fullname] = append(interfaces[iface.Fullname], impl.TypeUrl)
+			names = append(names, typeNameFromURL(impl.TypeUrl))
+		}
+	}
+	if err := d.fetch(ctx, names); err != nil {
+		return err
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.msgs = msgs
+	d.interfaces = interfaces
+	return nil
+}
+
+// Returns the type urls of the messages accepted in transactions, as reported by the last call to `Discover`
+func (d *DynamicTypes) MsgTypeURLs() []string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return append([]string(nil), d.msgs...)
+}
+
+// Returns the type urls of the implementations of the interface (ie: `cosmos.auth.v1beta1.AccountI`), as
+// reported by the last call to `Discover`
+func (d *DynamicTypes) InterfaceImplementations(name string) []string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return append([]string(nil), d.interfaces[name]...)
+}
+
+// Returns the type of the message with the given fully qualified name or type url, fetching its descriptor
+// from the node if it is not known
+func (d *DynamicTypes) MessageType(ctx context.Context, name string) (protoreflect.MessageType, error) {
+	name = typeNameFromURL(name)
+	if desc, ok := d.findMessage(name); ok {
+		return dynamicpb.NewMessageType(desc), nil
+	}
+	if err := d.fetch(ctx, []string{name}); err != nil {
+		return nil, err
+	}
+	desc, ok := d.findMessage(name)
+	if !ok {
+		return nil, fmt.Errorf("message type %s not found", name)
+	}
+	return dynamicpb.NewMessageType(desc), nil
+}
+
+// Decodes the packed message into a dynamic message
+func (d *DynamicTypes) Unpack(ctx context.Context, packed *codectypes.Any) (proto.Message, error) {
+	if packed == nil {
+		return nil, fmt.Errorf("cannot unpack nil any")
+	}
+	msgType, err := d.MessageType(ctx, packed.TypeUrl)
+	if err != nil {
+		return nil, err
+	}
+	msg := msgType.New().Interface()
+	if err := (proto.UnmarshalOptions{Resolver: d.resolver(ctx)}).Unmarshal(packed.Value, msg); err != nil {
+		return nil, fmt.Errorf("failed to decode %s %s", packed.TypeUrl, err)
+	}
+	return msg, nil
+}
+
+// Returns the json encoding of the packed message, including its `@type`. Nested messages of unknown
+// types are resolved through the node as well
+func (d *DynamicTypes) AnyToJSON(ctx context.Context, packed *codectypes.Any) (json.RawMessage, error) {
+	if packed == nil {
+		return nil, fmt.Errorf("cannot encode nil any")
+	}
+	bz, err := protojson.MarshalOptions{Resolver: d.resolver(ctx)}.Marshal(&anypb.Any{
+		TypeUrl: packed.TypeUrl,
+		Value:   packed.Value,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s %s", packed.TypeUrl, err)
+	}
+	return bz, nil
+}
+
+// Decodes the packed account, whose type need not be known to compass. The address, account number
+// and sequence are read from the account, or the first nested message defining them (ie: `base_account`)
+func (d *DynamicTypes) UnpackAccount(ctx context.Context, packed *codectypes.Any) (*DynamicAccount, error) {
+	msg, err := d.Unpack(ctx, packed)
+	if err != nil {
+		return nil, err
+	}
+	acc := &DynamicAccount{Message: msg}
+	m := msg.ProtoReflect()
+	if v, ok := findField(m, "address"); ok && v.String() != "" {
+		if _, acc.address, err = bech32.DecodeAndConvert(v.String()); err != nil {
+			return nil, fmt.Errorf("invalid account address %s", err)
+		}
+	}
+	if v, ok := findField(m, "account_number"); ok {
+		acc.accountNumber = v.Uint()
+	}
+	if v, ok := findField(m, "sequence"); ok {
+		acc.sequence = v.Uint()
+	}
+	return acc, nil
+}
+
+// Returns the json encoding of the packed message, including its `@type`. Types which are not registered
+// against the codec of the client are resolved through the node
+func (c *Client) AnyToJSON(ctx context.Context, packed *codectypes.Any) (json.RawMessage, error) {
+	if bz, err := c.Codec.Marshaler.MarshalJSON(packed); err == nil {
+		return bz, nil
+	}
+	return c.dynamicTypes().AnyToJSON(ctx, packed)
+}
+
+// An account whose type is not registered against the codec of the client
+type DynamicAccount struct {
+	// the decoded account
+	Message proto.Message
+
+	address       sdk.AccAddress
+	accountNumber uint64
+	sequence      uint64
+}
+
+func (a *DynamicAccount) GetAddress() sdk.AccAddress {
+	return a.address
+}
+
+// Returns nil, as the public key of dynamic accounts is not decoded
+func (a *DynamicAccount) GetPubKey() cryptotypes.PubKey {
+	return nil
+}
+
+func (a *DynamicAccount) GetAccountNumber() uint64 {
+	return a.accountNumber
+}
+
+func (a *DynamicAccount) GetSequence() uint64 {
+	return a.sequence
+}
+
+// returns the descriptor of the message, searching the descriptors fetched from the node before those
+// known to compass
+func (d *DynamicTypes) findMessage(name string) (protoreflect.MessageDescriptor, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	desc, err := d.descriptorResolver().FindDescriptorByName(protoreflect.FullName(name))
+	if err != nil {
+		return nil, false
+	}
+	msgDesc, ok := desc.(protoreflect.MessageDescriptor)
+	return msgDesc, ok
+}
+
+// fetches the files declaring the given types, and their dependencies, through gRPC server reflection
+func (d *DynamicTypes) fetch(ctx context.Context, names []string) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := reflectionpb.NewServerReflectionClient(d.conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to open reflection stream %s", err)
+	}
+	var fds []*descriptorpb.FileDescriptorProto
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		if _, ok := d.findMessage(name); ok {
+			continue
+		}
+		if err := stream.Send(&reflectionpb.ServerReflectionRequest{
+			MessageRequest: &reflectionpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: name},
+		}); err != nil {
+			return fmt.Errorf("failed to request descriptor of %s %s", name, err)
+		}
+		res, err := stream.Recv()
+		if err != nil {
+			return fmt.Errorf("failed to receive descriptor of %s %s", name, err)
+		}
+		if errRes := res.GetErrorResponse(); errRes != nil {
+			return fmt.Errorf("failed to fetch descriptor of %s: %s", name, errRes.ErrorMessage)
+		}
+		for _, bz := range res.GetFileDescriptorResponse().GetFileDescriptorProto() {
+			fd := &descriptorpb.FileDescriptorProto{}
+			if err := proto.Unmarshal(bz, fd); err != nil {
+				return fmt.Errorf("invalid descriptor of %s %s", name, err)
+			}
+			fds = append(fds, fd)
+		}
+	}
+	if err := stream.CloseSend(); err != nil {
+		return fmt.Errorf("failed to close reflection stream %s", err)
+	}
+	return d.register(fds)
+}
+
+// registers the fetched files in dependency order, skipping those which are already known
+func (d *DynamicTypes) register(fds []*descriptorpb.FileDescriptorProto) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	resolver := d.descriptorResolver()
+	pending := make(map[string]*descriptorpb.FileDescriptorProto, len(fds))
+	for _, fd := range fds {
+		if _, err := resolver.FindFileByPath(fd.GetName()); err == nil {
+			continue
+		}
+		pending[fd.GetName()] = fd
+	}
+	var registerFile func(path string) error
+	registerFile = func(path string) error {
+		fd, ok := pending[path]
+		if !ok {
+			return nil
+		}
+		delete(pending, path)
+		for _, dep := range fd.Dependency {
+			if err := registerFile(dep); err != nil {
+				return err
+			}
+		}
+		// descriptors of option only imports (ie: gogoproto) are not always served
+		file, err := protodesc.FileOptions{AllowUnresolvable: true}.New(fd, resolver)
+		if err != nil {
+			return fmt.Errorf("invalid descriptor %s %s", path, err)
+		}
+		return d.files.RegisterFile(file)
+	}
+	paths := make([]string, 0, len(pending))
+	for path := range pending {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		if err := registerFile(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// must be called with the lock held
+func (d *DynamicTypes) descriptorResolver() protodesc.Resolver {
+	return descriptorResolver{d.files}
+}
+
+// returns a resolver of message types for encoding and decoding nested messages
+func (d *DynamicTypes) resolver(ctx context.Context) *typeResolver {
+	return &typeResolver{ctx: ctx, types: d}
+}
+
+// resolves descriptors fetched from the node, falling back to those known to compass
+type descriptorResolver struct {
+	files *protoregistry.Files
+}
+
+func (r descriptorResolver) FindFileByPath(path string) (protoreflect.FileDescriptor, error) {
+	if fd, err := r.files.FindFileByPath(path); err == nil {
+		return fd, nil
+	}
+	return gogoproto.HybridResolver.FindFileByPath(path)
+}
+
+func (r descriptorResolver) FindDescriptorByName(name protoreflect.FullName) (protoreflect.Descriptor, error) {
+	if desc, err := r.files.FindDescriptorByName(name); err == nil {
+		return desc, nil
+	}
+	return gogoproto.HybridResolver.FindDescriptorByName(name)
+}
+
+// resolves message types as dynamic messages, fetching unknown types from the node
+type typeResolver struct {
+	ctx   context.Context
+	types *DynamicTypes
+}
+
+func (r *typeResolver) FindMessageByName(name protoreflect.FullName) (protoreflect.MessageType, error) {
+	return r.types.MessageType(r.ctx, string(name))
+}
+
+func (r *typeResolver) FindMessageByURL(url string) (protoreflect.MessageType, error) {
+	return r.types.MessageType(r.ctx, url)
+}
+
+func (r *typeResolver) FindExtensionByName(field protoreflect.FullName) (protoreflect.ExtensionType, error) {
+	return nil, protoregistry.NotFound
+}
+
+func (r *typeResolver) FindExtensionByNumber(message protoreflect.FullName, field protoreflect.FieldNumber) (protoreflect.ExtensionType, error) {
+	return nil, protoregistry.NotFound
+}
+
+// returns the value of the named field of the message, searching populated nested messages depth first
+func findField(m protoreflect.Message, name protoreflect.Name) (protoreflect.Value, bool) {
+	if fd := m.Descriptor().Fields().ByName(name); fd != nil && fd.Kind() != protoreflect.MessageKind && !fd.IsList() && !fd.IsMap() {
+		return m.Get(fd), true
+	}
+	var (
+		value protoreflect.Value
+		found bool
+	)
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if fd.Kind() != protoreflect.MessageKind || fd.IsList() || fd.IsMap() {
+			return true
+		}
+		value, found = findField(v.Message(), name)
+		return !found
+	})
+	return value, found
+}
+
+// returns the fully qualified message name of the type url
+func typeNameFromURL(url string) string {
+	return url[strings.LastIndex(url, "/")+1:]
+}
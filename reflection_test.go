@@ -0,0 +1,185 @@
+package compass_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	msgv1 "cosmossdk.io/api/cosmos/msg/v1"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	reflectionv2 "github.com/cosmos/cosmos-sdk/server/grpc/reflection/v2alpha1"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	gogoproto "github.com/cosmos/gogoproto/proto"
+	"github.com/stretchr/testify/require"
+	"github.com/teamscanworks/compass"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/reflection"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// descriptor of an app chain specific module which is unknown to compass
+var customFile = &descriptorpb.FileDescriptorProto{
+	Name:       proto.String("compass/test/v1/custom.proto"),
+	Package:    proto.String("compass.test.v1"),
+	Dependency: []string{"cosmos/auth/v1beta1/auth.proto"},
+	Syntax:     proto.String("proto3"),
+	MessageType: []*descriptorpb.DescriptorProto{
+		{
+			Name:    proto.String("MsgCustom"),
+			Options: signerOptions("signer"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				{Name: proto.String("signer"), JsonName: proto.String("signer"), Number: proto.Int32(1), Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(), Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()},
+				{Name: proto.String("amount"), JsonName: proto.String("amount"), Number: proto.Int32(2), Type: descriptorpb.FieldDescriptorProto_TYPE_UINT64.Enum(), Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()},
+			},
+		},
+		{
+			Name: proto.String("CustomAccount"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				{Name: proto.String("base_account"), JsonName: proto.String("baseAccount"), Number: proto.Int32(1), Type: descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(), TypeName: proto.String(".cosmos.auth.v1beta1.BaseAccount"), Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()},
+				{Name: proto.String("code_hash"), JsonName: proto.String("codeHash"), Number: proto.Int32(2), Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(), Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()},
+			},
+		},
+	},
+}
+
+// returns message options declaring the signer field of a message
+func signerOptions(field string) *descriptorpb.MessageOptions {
+	opts := &descriptorpb.MessageOptions{}
+	proto.SetExtension(opts, msgv1.E_Signer, []string{field})
+	return opts
+}
+
+// serves the custom descriptor alongside those known to compass
+type customResolver struct {
+	file protoreflect.FileDescriptor
+}
+
+func (r customResolver) FindFileByPath(path string) (protoreflect.FileDescriptor, error) {
+	if path == r.file.Path() {
+		return r.file, nil
+	}
+	return gogoproto.HybridResolver.FindFileByPath(path)
+}
+
+func (r customResolver) FindDescriptorByName(name protoreflect.FullName) (protoreflect.Descriptor, error) {
+	if desc := r.file.Messages().ByName(name.Name()); desc != nil && desc.FullName() == name {
+		return desc, nil
+	}
+	return gogoproto.HybridResolver.FindDescriptorByName(name)
+}
+
+type reflectionService struct {
+	reflectionv2.UnimplementedReflectionServiceServer
+}
+
+func (reflectionService) GetTxDescriptor(context.Context, *reflectionv2.GetTxDescriptorRequest) (*reflectionv2.GetTxDescriptorResponse, error) {
+	return &reflectionv2.GetTxDescriptorResponse{Tx: &reflectionv2.TxDescriptor{
+		Fullname: "cosmos.tx.v1beta1.Tx",
+		Msgs: []*reflectionv2.MsgDescriptor{
+			{MsgTypeUrl: "/cosmos.bank.v1beta1.MsgSend"},
+			{MsgTypeUrl: "/compass.test.v1.MsgCustom"},
+		},
+	}}, nil
+}
+
+func (reflectionService) GetCodecDescriptor(context.Context, *reflectionv2.GetCodecDescriptorRequest) (*reflectionv2.GetCodecDescriptorResponse, error) {
+	return &reflectionv2.GetCodecDescriptorResponse{Codec: &reflectionv2.CodecDescriptor{
+		Interfaces: []*reflectionv2.InterfaceDescriptor{{
+			Fullname: "cosmos.auth.v1beta1.AccountI",
+			InterfaceImplementers: []*reflectionv2.InterfaceImplementerDescriptor{
+				{Fullname: "cosmos.auth.v1beta1.BaseAccount", TypeUrl: "/cosmos.auth.v1beta1.BaseAccount"},
+				{Fullname: "compass.test.v1.CustomAccount", TypeUrl: "/compass.test.v1.CustomAccount"},
+			},
+		}},
+	}}, nil
+}
+
+// registers the reflection services of a chain declaring the custom types, returning the custom descriptor
+func registerReflection(t *testing.T, srv *grpc.Server) protoreflect.FileDescriptor {
+	file, err := protodesc.NewFile(customFile, gogoproto.HybridResolver)
+	require.NoError(t, err)
+	reflectionpb.RegisterServerReflectionServer(srv, reflection.NewServer(reflection.ServerOptions{
+		Services:           srv,
+		DescriptorResolver: customResolver{file},
+		ExtensionResolver:  new(protoregistry.Types),
+	}))
+	reflectionv2.RegisterReflectionServiceServer(srv, &reflectionService{})
+	return file
+}
+
+func newReflectionServer(t *testing.T) (*grpc.ClientConn, protoreflect.FileDescriptor) {
+	srv := grpc.NewServer()
+	file := registerReflection(t, srv)
+	lis := bufconn.Listen(1 << 20)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.Dial(
+		"bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn, file
+}
+
+func TestDynamicTypes(t *testing.T) {
+	conn, file := newReflectionServer(t)
+	ctx := context.Background()
+	types := compass.NewDynamicTypes(conn)
+
+	require.NoError(t, types.Discover(ctx))
+	require.Equal(t, []string{"/cosmos.bank.v1beta1.MsgSend", "/compass.test.v1.MsgCustom"}, types.MsgTypeURLs())
+	require.Contains(t, types.InterfaceImplementations("cosmos.auth.v1beta1.AccountI"), "/compass.test.v1.CustomAccount")
+
+	signer := sdk.MustBech32ifyAddressBytes("cosmos", []byte("signer_address______"))
+	msg := dynamicpb.NewMessage(file.Messages().ByName("MsgCustom"))
+	msg.Set(msg.Descriptor().Fields().ByName("signer"), protoreflect.ValueOfString(signer))
+	msg.Set(msg.Descriptor().Fields().ByName("amount"), protoreflect.ValueOfUint64(5))
+	value, err := proto.Marshal(msg)
+	require.NoError(t, err)
+	packed := &codectypes.Any{TypeUrl: "/compass.test.v1.MsgCustom", Value: value}
+
+	bz, err := types.AnyToJSON(ctx, packed)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"@type":"/compass.test.v1.MsgCustom","signer":"`+signer+`","amount":"5"}`, string(bz))
+
+	_, err = types.AnyToJSON(ctx, &codectypes.Any{TypeUrl: "/compass.test.v1.Missing"})
+	require.Error(t, err)
+}
+
+func TestDynamicTypesUnpackAccount(t *testing.T) {
+	conn, file := newReflectionServer(t)
+	ctx := context.Background()
+	// types are resolved lazily without discovery
+	types := compass.NewDynamicTypes(conn)
+
+	addr := sdk.AccAddress([]byte("account_address_____"))
+	accDesc := file.Messages().ByName("CustomAccount")
+	baseDesc := accDesc.Fields().ByName("base_account").Message()
+	baseAcc := dynamicpb.NewMessage(baseDesc)
+	baseAcc.Set(baseDesc.Fields().ByName("address"), protoreflect.ValueOfString(sdk.MustBech32ifyAddressBytes("evmos", addr)))
+	baseAcc.Set(baseDesc.Fields().ByName("account_number"), protoreflect.ValueOfUint64(7))
+	baseAcc.Set(baseDesc.Fields().ByName("sequence"), protoreflect.ValueOfUint64(3))
+	acc := dynamicpb.NewMessage(file.Messages().ByName("CustomAccount"))
+	acc.Set(accDesc.Fields().ByName("base_account"), protoreflect.ValueOfMessage(baseAcc))
+	acc.Set(accDesc.Fields().ByName("code_hash"), protoreflect.ValueOfString("0xc5d2"))
+	value, err := proto.Marshal(acc)
+	require.NoError(t, err)
+
+	account, err := types.UnpackAccount(ctx, &codectypes.Any{TypeUrl: "/compass.test.v1.CustomAccount", Value: value})
+	require.NoError(t, err)
+	require.Equal(t, addr, account.GetAddress())
+	require.Equal(t, uint64(7), account.GetAccountNumber())
+	require.Equal(t, uint64(3), account.GetSequence())
+	require.Nil(t, account.GetPubKey())
+}
@@ -0,0 +1,220 @@
+package compass
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// snapshot of the cosmos chain-registry (https://github.com/cosmos/chain-registry) for
+// the chains compass provides presets for
+//
+//go:embed chainregistry
+var chainRegistrySnapshot embed.FS
+
+// The contents of a chain-registry `chain.json` file which are relevant to compass
+type RegistryChain struct {
+	ChainName    string `json:"chain_name"`
+	ChainID      string `json:"chain_id"`
+	PrettyName   string `json:"pretty_name"`
+	NetworkType  string `json:"network_type"`
+	Bech32Prefix string `json:"bech32_prefix"`
+	Slip44       int    `json:"slip44"`
+	Fees         struct {
+		FeeTokens []RegistryFeeToken `json:"fee_tokens"`
+	} `json:"fees"`
+	Staking struct {
+		StakingTokens []struct {
+			Denom string `json:"denom"`
+		} `json:"staking_tokens"`
+	} `json:"staking"`
+	Apis struct {
+		RPC  []RegistryEndpoint `json:"rpc"`
+		Rest []RegistryEndpoint `json:"rest"`
+		GRPC []RegistryEndpoint `json:"grpc"`
+	} `json:"apis"`
+}
+
+// A token which may be used for paying transaction fees
+type RegistryFeeToken struct {
+	Denom            string  `json:"denom"`
+	FixedMinGasPrice float64 `json:"fixed_min_gas_price"`
+	LowGasPrice      float64 `json:"low_gas_price"`
+	AverageGasPrice  float64 `json:"average_gas_price"`
+	HighGasPrice     float64 `json:"high_gas_price"`
+}
+
+// Returns the gas price used for transactions, preferring the average gas price
+func (ft RegistryFeeToken) GasPrice() float64 {
+	for _, price := range []float64{ft.AverageGasPrice, ft.LowGasPrice, ft.FixedMinGasPrice} {
+		if price > 0 {
+			return price
+		}
+	}
+	return 0
+}
+
+// A publicly available node endpoint
+type RegistryEndpoint struct {
+	Address  string `json:"address"`
+	Provider string `json:"provider"`
+}
+
+// The contents of a chain-registry `assetlist.json` file
+type RegistryAssetList struct {
+	ChainName string          `json:"chain_name"`
+	Assets    []RegistryAsset `json:"assets"`
+}
+
+// Describes an asset, and the denominations it is displayed in
+type RegistryAsset struct {
+	Base       string `json:"base"`
+	Display    string `json:"display"`
+	Name       string `json:"name"`
+	Symbol     string `json:"symbol"`
+	DenomUnits []struct {
+		Denom    string `json:"denom"`
+		Exponent uint32 `json:"exponent"`
+	} `json:"denom_units"`
+}
+
+// A parsed chain-registry entry, consisting of the chain information and optionally its asset list
+type ChainRegistryEntry struct {
+	Chain     RegistryChain
+	AssetList *RegistryAssetList
+}
+
+// Parses the contents of the chain-registry `chain.json` and `assetlist.json` files of a chain.
+// The asset list is optional, and is ignored when nil
+func ParseChainRegistry(chainJSON, assetListJSON []byte) (*ChainRegistryEntry, error) {
+	entry := &ChainRegistryEntry{}
+	if err := json.Unmarshal(chainJSON, &entry.Chain); err != nil {
+		return nil, fmt.Errorf("failed to parse chain.json %s", err)
+	}
+	if entry.Chain.ChainID == "" {
+		return nil, fmt.Errorf("chain.json is missing chain_id")
+	}
+	if assetListJSON != nil {
+		entry.AssetList = &RegistryAssetList{}
+		if err := json.Unmarshal(assetListJSON, entry.AssetList); err != nil {
+			return nil, fmt.Errorf("failed to parse assetlist.json %s", err)
+		}
+	}
+	return entry, nil
+}
+
+// Returns the asset with the given base denomination, or nil if there is no such asset
+func (e *ChainRegistryEntry) Asset(base string) *RegistryAsset {
+	if e.AssetList == nil {
+		return nil
+	}
+	for i := range e.AssetList.Assets {
+		if e.AssetList.Assets[i].Base == base {
+			return &e.AssetList.Assets[i]
+		}
+	}
+	return nil
+}
+
+// Returns the denominations which may be used to pay transaction fees
+func (e *ChainRegistryEntry) FeeDenoms() []string {
+	denoms := make([]string, 0, len(e.Chain.Fees.FeeTokens))
+	for _, token := range e.Chain.Fees.FeeTokens {
+		denoms = append(denoms, token.Denom)
+	}
+	return denoms
+}
+
+// Returns a client configuration for the chain. The first endpoints listed in the registry are used
+// as the primary RPC and gRPC addresses, with the remainder used as alternates. The gas prices of all
+// fee tokens are used, in the order of the registry
+func (e *ChainRegistryEntry) ClientConfig(keyHome string) (*ClientConfig, error) {
+	chain := e.Chain
+	if len(chain.Apis.RPC) == 0 {
+		return nil, fmt.Errorf("chain %s has no rpc endpoints", chain.ChainID)
+	}
+	if len(chain.Apis.GRPC) == 0 {
+		return nil, fmt.Errorf("chain %s has no grpc endpoints", chain.ChainID)
+	}
+	gasPrices := make([]string, 0, len(chain.Fees.FeeTokens))
+	for _, token := range chain.Fees.FeeTokens {
+		gasPrices = append(gasPrices, strconv.FormatFloat(token.GasPrice(), 'f', -1, 64)+token.Denom)
+	}
+	cfg := &ClientConfig{
+		Key:            "default",
+		ChainID:        chain.ChainID,
+		RPCAddr:        chain.Apis.RPC[0].Address,
+		GRPCAddr:       chain.Apis.GRPC[0].Address,
+		AccountPrefix:  chain.Bech32Prefix,
+		KeyringBackend: "test",
+		GasAdjustment:  1.2,
+		GasPrices:      strings.Join(gasPrices, ","),
+		MinGasAmount:   0,
+		KeyDirectory:   keyHome,
+		Timeout:        "20s",
+		OutputFormat:   "json",
+		SignModeStr:    "direct",
+		Modules:        ModuleBasics,
+		Slip44:         chain.Slip44,
+	}
+	for _, endpoint := range chain.Apis.RPC[1:] {
+		cfg.RPCAddrs = append(cfg.RPCAddrs, endpoint.Address)
+	}
+	for _, endpoint := range chain.Apis.GRPC[1:] {
+		cfg.GRPCAddrs = append(cfg.GRPCAddrs, endpoint.Address)
+	}
+	cfg.SetKeysDir(keyHome)
+	return cfg, nil
+}
+
+// Loads the registry entry of the named chain from a local checkout of the chain-registry
+func LoadChainRegistry(registryDir, chainName string) (*ChainRegistryEntry, error) {
+	return readChainRegistry(os.DirFS(filepath.Join(registryDir, chainName)))
+}
+
+// Loads the registry entry of the named chain from the snapshot embedded in compass
+func GetChainRegistry(chainName string) (*ChainRegistryEntry, error) {
+	dir, err := fs.Sub(chainRegistrySnapshot, path.Join("chainregistry", chainName))
+	if err != nil {
+		return nil, err
+	}
+	return readChainRegistry(dir)
+}
+
+// Returns a configuration for the named chain, loaded from a local checkout of the chain-registry
+func LoadChainRegistryConfig(registryDir, chainName, keyHome string) (*ClientConfig, error) {
+	entry, err := LoadChainRegistry(registryDir, chainName)
+	if err != nil {
+		return nil, err
+	}
+	return entry.ClientConfig(keyHome)
+}
+
+// Returns a configuration for the named chain, loaded from the chain-registry snapshot embedded in compass
+func GetChainRegistryConfig(chainName, keyHome string) (*ClientConfig, error) {
+	entry, err := GetChainRegistry(chainName)
+	if err != nil {
+		return nil, err
+	}
+	return entry.ClientConfig(keyHome)
+}
+
+// reads the chain.json, and the optional assetlist.json from the given directory
+func readChainRegistry(dir fs.FS) (*ChainRegistryEntry, error) {
+	chainJSON, err := fs.ReadFile(dir, "chain.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read chain.json %s", err)
+	}
+	assetListJSON, err := fs.ReadFile(dir, "assetlist.json")
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read assetlist.json %s", err)
+	}
+	return ParseChainRegistry(chainJSON, assetListJSON)
+}
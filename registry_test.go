@@ -0,0 +1,72 @@
+package compass_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/teamscanworks/compass"
+)
+
+func TestChainRegistry(t *testing.T) {
+	t.Run("Fixture", func(t *testing.T) {
+		entry, err := compass.LoadChainRegistry(filepath.Join("testdata", "chainregistry"), "testchain")
+		require.NoError(t, err)
+		require.Equal(t, []string{"utest", "ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2"}, entry.FeeDenoms())
+		asset := entry.Asset("utest")
+		require.NotNil(t, asset)
+		require.Equal(t, "TEST", asset.Symbol)
+		require.Nil(t, entry.Asset("uother"))
+
+		cfg, err := entry.ClientConfig("home")
+		require.NoError(t, err)
+		require.NoError(t, cfg.Validate())
+		require.Equal(t, "testchain-7", cfg.ChainID)
+		require.Equal(t, "test", cfg.AccountPrefix)
+		require.Equal(t, 529, cfg.Slip44)
+		// the average gas price of utest is zero, so its fixed minimum gas price is used
+		require.Equal(t, "0.001utest,0.0005ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2", cfg.GasPrices)
+		require.Equal(t, "https://rpc-1.testchain.example.com:443", cfg.RPCAddr)
+		require.Equal(t, []string{"https://rpc-2.testchain.example.com:443"}, cfg.RPCAddrs)
+		require.Equal(t, "grpc-1.testchain.example.com:9090", cfg.GRPCAddr)
+		require.Empty(t, cfg.GRPCAddrs)
+		require.Equal(t, filepath.Join("home", "keys", "testchain-7"), cfg.KeyDirectory)
+	})
+	t.Run("Missing", func(t *testing.T) {
+		_, err := compass.LoadChainRegistryConfig(filepath.Join("testdata", "chainregistry"), "missing", "home")
+		require.Error(t, err)
+		_, err = compass.GetChainRegistryConfig("missing", "home")
+		require.Error(t, err)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := compass.ParseChainRegistry([]byte(`{"chain_name": "nochainid"}`), nil)
+		require.Error(t, err)
+		entry, err := compass.ParseChainRegistry([]byte(`{"chain_id": "noapis-1"}`), nil)
+		require.NoError(t, err)
+		_, err = entry.ClientConfig("home")
+		require.Error(t, err)
+	})
+	t.Run("Snapshot", func(t *testing.T) {
+		presets := map[string]func(string, bool) *compass.ClientConfig{
+			"cosmoshub": compass.GetCosmosHubConfig,
+			"osmosis":   compass.GetOsmosisConfig,
+		}
+		for chainName, chainID := range map[string]string{"cosmoshub": "cosmoshub-4", "osmosis": "osmosis-1"} {
+			cfg, err := compass.GetChainRegistryConfig(chainName, "home")
+			require.NoError(t, err)
+			require.Equal(t, chainID, cfg.ChainID)
+			require.NoError(t, cfg.Validate())
+
+			// presets are the parsed snapshot, returned as independent copies
+			preset := presets[chainName]("home", true)
+			require.True(t, preset.Debug)
+			require.Equal(t, cfg.KeyDirectory, preset.KeyDirectory)
+			require.Equal(t, cfg.RPCAddrs, preset.RPCAddrs)
+			require.Equal(t, cfg.GRPCAddrs, preset.GRPCAddrs)
+			require.NoError(t, preset.Validate())
+			preset.RPCAddrs[0] = "http://modified:26657"
+			require.Equal(t, cfg.RPCAddrs, presets[chainName]("home", false).RPCAddrs)
+		}
+		require.Equal(t, "0.025uosmo", compass.GetOsmosisConfig("home", false).GasPrices)
+	})
+}
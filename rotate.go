@@ -0,0 +1,374 @@
+package compass
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	"go.uber.org/zap"
+
+	"github.com/teamscanworks/compass/types/feegrant"
+)
+
+const (
+	// withdrawal of staking rewards earned by the old key
+	RotationStepWithdrawRewards = "withdraw-rewards"
+	// revocation of authz grants and feegrant allowances issued by the old key
+	RotationStepRevokeGrants = "revoke-grants"
+	// transfer of all bank balances from the old key to the new key
+	RotationStepTransfer = "transfer"
+	// re-issuance of authz grants and feegrant allowances from the new key
+	RotationStepReissueGrants = "reissue-grants"
+)
+
+// Records a transaction sent as part of a key rotation
+type RotationTx struct {
+	Step   string   `json:"step" yaml:"step"`
+	Signer string   `json:"signer" yaml:"signer"`
+	TxHash string   `json:"tx-hash" yaml:"tx-hash"`
+	Msgs   []string `json:"msgs" yaml:"msgs"`
+}
+
+// Summarizes the actions taken while rotating a key
+type RotationReport struct {
+	OldAddress string `json:"old-address" yaml:"old-address"`
+	NewAddress string `json:"new-address" yaml:"new-address"`
+	// mnemonic of the newly created key, which should be backed up by the caller
+	Mnemonic     string       `json:"mnemonic" yaml:"mnemonic"`
+	Transferred  sdk.Coins    `json:"transferred" yaml:"transferred"`
+	Transactions []RotationTx `json:"transactions" yaml:"transactions"`
+	// grants and allowances revoked from the old key which have not been re-issued from the new key, as the
+	// rotation failed in between. They may be re-issued by broadcasting these messages signed by the new key
+	Unissued []sdk.Msg `json:"-" yaml:"-"`
+}
+
+// Allows customizing the behavior of `RotateKey`
+type RotateKeyOption func(*rotateKeyOptions)
+
+type rotateKeyOptions struct {
+	withdrawRewards bool
+	coinType        uint32
+}
+
+// Withdraws all staking rewards earned by the old key before transferring balances.
+//
+// NOTE: delegations themselves can not be moved between accounts, so they remain with the old key
+func WithRewardsWithdrawal() RotateKeyOption {
+	return func(opts *rotateKeyOptions) {
+		opts.withdrawRewards = true
+	}
+}
+
+// Overrides the coin type used to derive the new key, defaulting to `ClientConfig.Slip44`
+func WithRotationCoinType(coinType uint32) RotateKeyOption {
+	return func(opts *rotateKeyOptions) {
+		opts.coinType = coinType
+	}
+}
+
+// Rotates the key `oldName` to a newly created key `newName`. This
+//
+//  1. creates the new key
+//  2. optionally withdraws staking rewards earned by the old key
+//  3. revokes all authz grants, and feegrant allowances issued by the old key
+//  4. transfers all bank balances (minus fees) from the old key to the new key
+//  5. re-issues the revoked grants and allowances from the new key
+//
+// If the old key is in use for signing transactions, the client is switched over to the new key.
+// When an error is encountered the report of all transactions sent so far is returned alongside it,
+// listing any revoked grants which were not re-issued under `Unissued`.
+func (c *Client) RotateKey(ctx context.Context, oldName, newName string, opts ...RotateKeyOption) (*RotationReport, error) {
+	if err := c.checkClosed(); err != nil {
+		return nil, err
+	}
+	options := rotateKeyOptions{coinType: c.coinType()}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	c.txLock.Lock()
+	defer c.txLock.Unlock()
+
+	oldRecord, err := c.Keyring.Key(oldName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lookup key %s %v", oldName, err)
+	}
+	oldAddr, err := oldRecord.GetAddress()
+	if err != nil {
+		return nil, err
+	}
+	if c.KeyExists(newName) {
+		return nil, fmt.Errorf("key %s already exists", newName)
+	}
+	oldBech32, err := c.EncodeBech32AccAddr(oldAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	// snapshot grants prior to making any changes
+	authzGrants, err := c.granterAuthzGrants(ctx, oldBech32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query authz grants %v", err)
+	}
+	allowances, err := c.granterAllowances(ctx, oldBech32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query feegrant allowances %v", err)
+	}
+
+	ko, err := c.KeyAddOrRestore(newName, options.coinType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create key %s %v", newName, err)
+	}
+	newAddr, err := c.DecodeBech32AccAddr(ko.Address)
+	if err != nil {
+		return nil, err
+	}
+	report := &RotationReport{
+		OldAddress: oldBech32,
+		NewAddress: ko.Address,
+		Mnemonic:   ko.Mnemonic,
+	}
+	c.log.Info("rotating key", zap.String("old.address", oldBech32), zap.String("new.address", ko.Address))
+
+	send := func(step, signer string, signerAddr sdk.AccAddress, gasPrice *sdk.DecCoin, msgs []sdk.Msg) error {
+		if len(msgs) == 0 {
+			return nil
+		}
+		txHash, err := c.broadcastAs(ctx, signer, signerAddr, gasPrice, msgs...)
+		if err != nil {
+			return fmt.Errorf("failed to %s %v", step, err)
+		}
+		rtx := RotationTx{Step: step, Signer: signer, TxHash: txHash}
+		for _, msg := range msgs {
+			rtx.Msgs = append(rtx.Msgs, sdk.MsgTypeURL(msg))
+		}
+		report.Transactions = append(report.Transactions, rtx)
+		c.log.Info("sent key rotation transaction", zap.String("step", step), zap.String("tx.hash", txHash))
+		return nil
+	}
+
+	if options.withdrawRewards {
+		msgs, err := c.withdrawRewardsMsgs(ctx, oldBech32)
+		if err != nil {
+			return report, fmt.Errorf("failed to query staking rewards %v", err)
+		}
+		if err := send(RotationStepWithdrawRewards, oldName, oldAddr, nil, msgs); err != nil {
+			return report, err
+		}
+	}
+
+	var revokeMsgs, reissueMsgs []sdk.Msg
+	for _, grant := range authzGrants {
+		if grant.Expiration != nil && grant.Expiration.Before(time.Now()) {
+			continue
+		}
+		var authorization authz.Authorization
+		if err := c.Codec.InterfaceRegistry.UnpackAny(grant.Authorization, &authorization); err != nil {
+			return report, fmt.Errorf("failed to unpack authorization %v", err)
+		}
+		revokeMsgs = append(revokeMsgs, &authz.MsgRevoke{
+			Granter:    oldBech32,
+			Grantee:    grant.Grantee,
+			MsgTypeUrl: authorization.MsgTypeURL(),
+		})
+		if grant.Grantee == ko.Address {
+			continue
+		}
+		reissueMsgs = append(reissueMsgs, &authz.MsgGrant{
+			Granter: ko.Address,
+			Grantee: grant.Grantee,
+			Grant: authz.Grant{
+				Authorization: grant.Authorization,
+				Expiration:    grant.Expiration,
+			},
+		})
+	}
+	for _, allowance := range allowances {
+		revokeMsgs = append(revokeMsgs, &feegrant.MsgRevokeAllowance{
+			Granter: oldBech32,
+			Grantee: allowance.Grantee,
+		})
+		if allowance.Grantee == ko.Address {
+			continue
+		}
+		reissueMsgs = append(reissueMsgs, &feegrant.MsgGrantAllowance{
+			Granter:   ko.Address,
+			Grantee:   allowance.Grantee,
+			Allowance: allowance.Allowance,
+		})
+	}
+	if err := send(RotationStepRevokeGrants, oldName, oldAddr, nil, revokeMsgs); err != nil {
+		return report, err
+	}
+	report.Unissued = reissueMsgs
+
+	balances, err := c.allBalances(ctx, oldBech32)
+	if err != nil {
+		return report, fmt.Errorf("failed to query balances %v", err)
+	}
+	gasPrice, err := c.selectGasPrice(ctx, oldBech32, c.factory.Gas())
+	if err != nil {
+		return report, err
+	}
+	fees := estimateFees(c.factory.WithGasPrices(gasPriceString(gasPrice)))
+	transfer, negative := balances.SafeSub(fees...)
+	if negative {
+		return report, fmt.Errorf("balances %s insufficient to cover transfer fees %s", balances, fees)
+	}
+	if !transfer.IsZero() {
+		// the transfer must pay the fees reserved for it, so it is sent at the gas price they were estimated at
+		if err := send(RotationStepTransfer, oldName, oldAddr, &gasPrice, []sdk.Msg{&banktypes.MsgSend{
+			FromAddress: oldBech32,
+			ToAddress:   ko.Address,
+			Amount:      transfer,
+		}}); err != nil {
+			return report, err
+		}
+		report.Transferred = transfer
+	}
+
+	if err := send(RotationStepReissueGrants, newName, newAddr, nil, reissueMsgs); err != nil {
+		return report, err
+	}
+	report.Unissued = nil
+
+	if c.cctx.GetFromName() == oldName {
+		c.cctx = c.cctx.WithFromName(newName).WithFromAddress(newAddr)
+		c.factory = c.factory.WithAccountNumber(0).WithSequence(0)
+	}
+	return report, nil
+}
+
+// Broadcasts the given messages signed by the named key at the given gas price if any, failing if the
+// transaction was not successfully executed. The previously configured signer is restored afterwards.
+//
+// Callers must hold `txLock`
+func (c *Client) broadcastAs(ctx context.Context, keyName string, addr sdk.AccAddress, gasPrice *sdk.DecCoin, msgs ...sdk.Msg) (string, error) {
+	cctx, factory := c.cctx, c.factory
+	defer func() {
+		c.cctx, c.factory = cctx, factory
+	}()
+	c.cctx = c.cctx.WithFromName(keyName).WithFromAddress(addr)
+	// reset the account number and sequence so they are fetched for the signer
+	c.factory = c.factory.WithAccountNumber(0).WithSequence(0)
+
+	txHash, err := c.broadcastTx(ctx, "", gasPrice, msgs...)
+	if err != nil {
+		return "", err
+	}
+	if _, err := c.txResult(ctx, txHash); err != nil {
+		return txHash, err
+	}
+	return txHash, nil
+}
+
+// Returns the authz grants issued by the given granter
+func (c *Client) granterAuthzGrants(ctx context.Context, granter string) ([]*authz.GrantAuthorization, error) {
+	queryClient := authz.NewQueryClient(c.grpcConn())
+	var (
+		grants []*authz.GrantAuthorization
+		key    []byte
+	)
+	for {
+		res, err := queryClient.GranterGrants(ctx, &authz.QueryGranterGrantsRequest{
+			Granter:    granter,
+			Pagination: &query.PageRequest{Key: key},
+		})
+		if err != nil {
+			return nil, err
+		}
+		grants = append(grants, res.Grants...)
+		if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+			return grants, nil
+		}
+		key = res.Pagination.NextKey
+	}
+}
+
+// Returns the feegrant allowances issued by the given granter
+func (c *Client) granterAllowances(ctx context.Context, granter string) ([]*feegrant.Grant, error) {
+	queryClient := feegrant.NewQueryClient(c.grpcConn())
+	var (
+		allowances []*feegrant.Grant
+		key        []byte
+	)
+	for {
+		res, err := queryClient.AllowancesByGranter(ctx, &feegrant.QueryAllowancesByGranterRequest{
+			Granter:    granter,
+			Pagination: &query.PageRequest{Key: key},
+		})
+		if err != nil {
+			return nil, err
+		}
+		allowances = append(allowances, res.Allowances...)
+		if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+			return allowances, nil
+		}
+		key = res.Pagination.NextKey
+	}
+}
+
+// Returns all bank balances held by the given address
+func (c *Client) allBalances(ctx context.Context, address string) (sdk.Coins, error) {
+	queryClient := banktypes.NewQueryClient(c.grpcConn())
+	var (
+		balances sdk.Coins
+		key      []byte
+	)
+	for {
+		res, err := queryClient.AllBalances(ctx, &banktypes.QueryAllBalancesRequest{
+			Address:    address,
+			Pagination: &query.PageRequest{Key: key},
+		})
+		if err != nil {
+			return nil, err
+		}
+		balances = balances.Add(res.Balances...)
+		if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+			return balances, nil
+		}
+		key = res.Pagination.NextKey
+	}
+}
+
+// Returns messages withdrawing the staking rewards from every validator the delegator has earned rewards from
+func (c *Client) withdrawRewardsMsgs(ctx context.Context, delegator string) ([]sdk.Msg, error) {
+	res, err := distrtypes.NewQueryClient(c.grpcConn()).DelegationTotalRewards(ctx, &distrtypes.QueryDelegationTotalRewardsRequest{
+		DelegatorAddress: delegator,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var msgs []sdk.Msg
+	for _, reward := range res.Rewards {
+		if reward.Reward.IsZero() {
+			continue
+		}
+		msgs = append(msgs, &distrtypes.MsgWithdrawDelegatorReward{
+			DelegatorAddress: delegator,
+			ValidatorAddress: reward.ValidatorAddress,
+		})
+	}
+	return msgs, nil
+}
+
+// Returns the fees paid by transactions built with the given factory, mirroring the
+// calculation performed when building unsigned transactions
+func estimateFees(factory tx.Factory) sdk.Coins {
+	if !factory.Fees().IsZero() {
+		return factory.Fees()
+	}
+	gasLimit := sdkmath.LegacyNewDec(int64(factory.Gas()))
+	fees := sdk.NewCoins()
+	for _, gasPrice := range factory.GasPrices() {
+		fees = fees.Add(sdk.NewCoin(gasPrice.Denom, gasPrice.Amount.Mul(gasLimit).Ceil().RoundInt()))
+	}
+	return fees
+}
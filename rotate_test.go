@@ -0,0 +1,180 @@
+package compass_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	"github.com/stretchr/testify/require"
+	"github.com/teamscanworks/compass"
+	"github.com/teamscanworks/compass/types/feegrant"
+	"google.golang.org/grpc"
+)
+
+// serves the authz grants issued by a single granter
+type authzService struct {
+	authz.UnimplementedQueryServer
+	grants []*authz.GrantAuthorization
+}
+
+func (s *authzService) GranterGrants(_ context.Context, req *authz.QueryGranterGrantsRequest) (*authz.QueryGranterGrantsResponse, error) {
+	var grants []*authz.GrantAuthorization
+	for _, grant := range s.grants {
+		if grant.Granter == req.Granter {
+			grants = append(grants, grant)
+		}
+	}
+	return &authz.QueryGranterGrantsResponse{Grants: grants}, nil
+}
+
+// serves the feegrant allowances issued by a single granter
+type feegrantService struct {
+	feegrant.UnimplementedQueryServer
+	allowances []*feegrant.Grant
+}
+
+func (s *feegrantService) AllowancesByGranter(_ context.Context, req *feegrant.QueryAllowancesByGranterRequest) (*feegrant.QueryAllowancesByGranterResponse, error) {
+	var allowances []*feegrant.Grant
+	for _, allowance := range s.allowances {
+		if allowance.Granter == req.Granter {
+			allowances = append(allowances, allowance)
+		}
+	}
+	return &feegrant.QueryAllowancesByGranterResponse{Allowances: allowances}, nil
+}
+
+// serves the same staking rewards for every delegator
+type distributionService struct {
+	distrtypes.UnimplementedQueryServer
+	rewards []distrtypes.DelegationDelegatorReward
+}
+
+func (s *distributionService) DelegationTotalRewards(context.Context, *distrtypes.QueryDelegationTotalRewardsRequest) (*distrtypes.QueryDelegationTotalRewardsResponse, error) {
+	return &distrtypes.QueryDelegationTotalRewardsResponse{Rewards: s.rewards}, nil
+}
+
+func TestRotateKeyPreconditions(t *testing.T) {
+	client, _ := newMemoryClient(t)
+	_, err := client.AddKey("old", 118)
+	require.NoError(t, err)
+	_, err = client.AddKey("existing", 118)
+	require.NoError(t, err)
+
+	// unknown keys can't be rotated
+	_, err = client.RotateKey(context.Background(), "missing", "new")
+	require.Error(t, err)
+	// the new key must not already exist
+	_, err = client.RotateKey(context.Background(), "old", "existing")
+	require.Error(t, err)
+	require.False(t, client.KeyExists("new"))
+}
+
+func TestRotateKey(t *testing.T) {
+	balances := &balanceService{balances: sdk.NewCoins(sdk.NewInt64Coin("stake", 1_000_000), sdk.NewInt64Coin("uatom", 500))}
+	grants := &authzService{}
+	allowances := &feegrantService{}
+	rewards := &distributionService{rewards: []distrtypes.DelegationDelegatorReward{
+		{ValidatorAddress: "cosmosvaloper1rewards", Reward: sdk.NewDecCoins(sdk.NewInt64DecCoin("stake", 10))},
+		{ValidatorAddress: "cosmosvaloper1norewards"},
+	}}
+	client, node := newJournaledClient(t, func(srv *grpc.Server) {
+		banktypes.RegisterQueryServer(srv, balances)
+		authz.RegisterQueryServer(srv, grants)
+		feegrant.RegisterQueryServer(srv, allowances)
+		distrtypes.RegisterQueryServer(srv, rewards)
+	})
+	ctx := context.Background()
+	oldAddress := client.FromAddress()
+	grantee := sdk.MustBech32ifyAddressBytes("cosmos", []byte("grantee_address_____"))
+
+	authorization, err := codectypes.NewAnyWithValue(authz.NewGenericAuthorization(sdk.MsgTypeURL(&banktypes.MsgSend{})))
+	require.NoError(t, err)
+	expired := time.Now().Add(-time.Hour)
+	expiration := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	grants.grants = []*authz.GrantAuthorization{
+		{Granter: oldAddress, Grantee: grantee, Authorization: authorization, Expiration: &expiration},
+		{Granter: oldAddress, Grantee: grantee, Authorization: authorization, Expiration: &expired},
+	}
+	allowance, err := codectypes.NewAnyWithValue(&feegrant.BasicAllowance{SpendLimit: sdk.NewCoins(sdk.NewInt64Coin("stake", 100))})
+	require.NoError(t, err)
+	allowances.allowances = []*feegrant.Grant{{Granter: oldAddress, Grantee: grantee, Allowance: allowance}}
+
+	report, err := client.RotateKey(ctx, "default", "rotated", compass.WithRewardsWithdrawal())
+	require.NoError(t, err)
+	require.Equal(t, oldAddress, report.OldAddress)
+	newAddress, err := client.ShowAddress("rotated")
+	require.NoError(t, err)
+	require.Equal(t, newAddress, report.NewAddress)
+	require.NotEmpty(t, report.Mnemonic)
+
+	// the withdrawal, revocation and transfer are signed by the old key, the grants are reissued by the new key
+	steps := []struct {
+		step, signer string
+		msgs         []string
+	}{
+		{compass.RotationStepWithdrawRewards, "default", []string{sdk.MsgTypeURL(&distrtypes.MsgWithdrawDelegatorReward{})}},
+		{compass.RotationStepRevokeGrants, "default", []string{sdk.MsgTypeURL(&authz.MsgRevoke{}), sdk.MsgTypeURL(&feegrant.MsgRevokeAllowance{})}},
+		{compass.RotationStepTransfer, "default", []string{sdk.MsgTypeURL(&banktypes.MsgSend{})}},
+		{compass.RotationStepReissueGrants, "rotated", []string{sdk.MsgTypeURL(&authz.MsgGrant{}), sdk.MsgTypeURL(&feegrant.MsgGrantAllowance{})}},
+	}
+	require.Len(t, report.Transactions, len(steps))
+	require.Len(t, node.broadcasts, len(steps))
+	decoded := make([]*compass.DecodedTx, len(steps))
+	for i, step := range steps {
+		rtx := report.Transactions[i]
+		require.Equal(t, step.step, rtx.Step)
+		require.Equal(t, step.signer, rtx.Signer)
+		require.Equal(t, step.msgs, rtx.Msgs)
+		decoded[i], err = client.DecodeTx(node.broadcasts[i])
+		require.NoError(t, err)
+		require.Equal(t, rtx.TxHash, decoded[i].Hash)
+	}
+	require.Equal(t, []string{oldAddress}, decoded[0].Signers)
+	require.Equal(t, "cosmosvaloper1rewards", decoded[0].Msgs[0].Msg.(*distrtypes.MsgWithdrawDelegatorReward).ValidatorAddress)
+	require.Equal(t, []string{oldAddress}, decoded[1].Signers)
+	revoke := decoded[1].Msgs[0].Msg.(*authz.MsgRevoke)
+	require.Equal(t, grantee, revoke.Grantee)
+	require.Equal(t, sdk.MsgTypeURL(&banktypes.MsgSend{}), revoke.MsgTypeUrl)
+	require.Equal(t, grantee, decoded[1].Msgs[1].Msg.(*feegrant.MsgRevokeAllowance).Grantee)
+
+	// everything but the fees of the transfer is moved to the new key
+	send := decoded[2].Msgs[0].Msg.(*banktypes.MsgSend)
+	require.Equal(t, oldAddress, send.FromAddress)
+	require.Equal(t, newAddress, send.ToAddress)
+	require.Equal(t, balances.balances.Sub(decoded[2].Fee...), send.Amount)
+	require.Equal(t, send.Amount, report.Transferred)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 800_000), sdk.NewInt64Coin("uatom", 500)), report.Transferred)
+
+	require.Equal(t, []string{newAddress}, decoded[3].Signers)
+	grant := decoded[3].Msgs[0].Msg.(*authz.MsgGrant)
+	require.Equal(t, grantee, grant.Grantee)
+	require.Equal(t, &expiration, grant.Grant.Expiration)
+	require.Equal(t, authorization.Value, grant.Grant.Authorization.Value)
+	reissued := decoded[3].Msgs[1].Msg.(*feegrant.MsgGrantAllowance)
+	require.Equal(t, grantee, reissued.Grantee)
+	require.Equal(t, allowance.Value, reissued.Allowance.Value)
+
+	// the client signs with the new key from now on
+	require.Equal(t, newAddress, client.FromAddress())
+	require.Empty(t, report.Unissued)
+
+	// grants revoked before a failed transfer are reported as unissued
+	grants.grants = []*authz.GrantAuthorization{{Granter: newAddress, Grantee: grantee, Authorization: authorization}}
+	allowances.allowances = []*feegrant.Grant{{Granter: newAddress, Grantee: grantee, Allowance: allowance}}
+	node.mu.Lock()
+	node.broadcastResults = map[int]abci.ExecTxResult{len(node.broadcasts) + 1: {Code: 5, Log: "insufficient funds"}}
+	node.mu.Unlock()
+	report, err = client.RotateKey(ctx, "rotated", "failed")
+	require.ErrorContains(t, err, "insufficient funds")
+	require.Len(t, report.Transactions, 1)
+	require.Equal(t, compass.RotationStepRevokeGrants, report.Transactions[0].Step)
+	require.Len(t, report.Unissued, 2)
+	require.Equal(t, report.NewAddress, report.Unissued[0].(*authz.MsgGrant).Granter)
+	require.Equal(t, report.NewAddress, report.Unissued[1].(*feegrant.MsgGrantAllowance).Granter)
+}
@@ -0,0 +1,21 @@
+#! /bin/bash
+#
+# Generates gogoproto types for protobuf definitions which compass depends on, but which are not
+# available as go modules compatible with the version of the cosmos-sdk in use.
+#
+# Requires buf, and protoc-gen-gocosmos (github.com/cosmos/gogoproto/protoc-gen-gocosmos)
+set -eo pipefail
+
+cd proto
+
+# cosmos-sdk modules which have been split out into separate go modules
+buf generate --template buf.gen.gogo.yaml buf.build/cosmos/cosmos-sdk --path cosmos/feegrant/v1beta1/feegrant.proto --path cosmos/feegrant/v1beta1/query.proto --path cosmos/feegrant/v1beta1/tx.proto
+mkdir -p ../types/feegrant
+mv cosmossdk.io/x/feegrant/*.pb.go ../types/feegrant/
+rm -rf cosmossdk.io
+
+# protobuf definitions vendored under proto/ for modules which can not be imported alongside
+# the cosmos-sdk version in use
+buf generate --template buf.gen.gogo.yaml --path ibc --path cosmwasm --path osmosis
+cp -r github.com/teamscanworks/compass/* ../
+rm -rf github.com
@@ -0,0 +1,59 @@
+package compass
+
+import (
+	"context"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// name of the tracer spans are recorded with
+const tracerName = "github.com/teamscanworks/compass"
+
+// attributes set on spans
+const (
+	AttributeChainID   = attribute.Key("compass.chain_id")
+	AttributeSigner    = attribute.Key("compass.signer")
+	AttributeMsgTypes  = attribute.Key("compass.msg_types")
+	AttributeTxHash    = attribute.Key("compass.tx.hash")
+	AttributeTxCode    = attribute.Key("compass.tx.code")
+	AttributeGasWanted = attribute.Key("compass.tx.gas_wanted")
+	AttributeGasUsed   = attribute.Key("compass.tx.gas_used")
+	AttributeHeight    = attribute.Key("compass.tx.height")
+	AttributeMethod    = attribute.Key("compass.method")
+)
+
+// returns the tracer of the configured provider, falling back to the global provider which is a no-op
+// unless one is registered through `otel.SetTracerProvider`
+func (c *Client) tracer() trace.Tracer {
+	if c.cfg.TracerProvider != nil {
+		return c.cfg.TracerProvider.Tracer(tracerName)
+	}
+	return otel.GetTracerProvider().Tracer(tracerName)
+}
+
+// starts a span as a child of any span carried by the context
+func (c *Client) startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return c.tracer().Start(ctx, name, trace.WithAttributes(append(attrs, AttributeChainID.String(c.cfg.ChainID))...))
+}
+
+// ends the span, recording the error if any
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// returns the type URLs of the messages
+func msgTypeURLs(msgs []sdk.Msg) []string {
+	urls := make([]string, 0, len(msgs))
+	for _, msg := range msgs {
+		urls = append(urls, sdk.MsgTypeURL(msg))
+	}
+	return urls
+}
@@ -0,0 +1,45 @@
+package compass_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/stretchr/testify/require"
+	"github.com/teamscanworks/compass"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/zap"
+)
+
+func TestTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	logger, err := zap.NewDevelopment()
+	require.NoError(t, err)
+	node := newFakeNode(t, "testing")
+	cfg := newTestConfig(node.srv.URL, newNodeInfoServer(t, "testing"))
+	cfg.TracerProvider = provider
+	client, err := compass.NewClient(logger, cfg, []keyring.Option{compass.DefaultSignatureOptions()})
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close(context.Background()) })
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+	_, err = client.NodeHealth(ctx)
+	require.NoError(t, err)
+	require.NoError(t, client.Reconnect(ctx))
+	parent.End()
+
+	children := make(map[string]sdktrace.ReadOnlySpan)
+	for _, span := range recorder.Ended() {
+		if span.Parent().SpanID() == parent.SpanContext().SpanID() {
+			children[span.Name()] = span
+		}
+	}
+	for _, name := range []string{"status", "abci_info", "net_info", "/cosmos.base.tendermint.v1beta1.Service/GetNodeInfo"} {
+		span, ok := children[name]
+		require.True(t, ok, name)
+		require.Contains(t, span.Attributes(), compass.AttributeChainID.String("testing"))
+		require.Contains(t, span.Attributes(), compass.AttributeMethod.String(name))
+	}
+}
@@ -0,0 +1,362 @@
+package compass
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"cosmossdk.io/x/tx/signing"
+	comettypes "github.com/cometbft/cometbft/types"
+	"github.com/cosmos/cosmos-proto/anyutil"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	signingtypes "github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authcodec "github.com/cosmos/cosmos-sdk/x/auth/codec"
+	"github.com/cosmos/cosmos-sdk/x/auth/migrations/legacytx"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	gogoproto "github.com/cosmos/gogoproto/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// The encoding of a decoded transaction
+type TxEncoding string
+
+const (
+	// binary protobuf encoding, as included in blocks
+	TxEncodingProto TxEncoding = "proto"
+	// protobuf json encoding
+	TxEncodingProtoJSON TxEncoding = "proto-json"
+	// amino json encoding of the legacy `StdTx`
+	TxEncodingAminoJSON TxEncoding = "amino-json"
+)
+
+// A structured view of a transaction, which renders as readable json
+type DecodedTx struct {
+	Encoding TxEncoding `json:"encoding"`
+	// hex encoded hash of the transaction, only set for the binary protobuf encoding
+	Hash string       `json:"hash,omitempty"`
+	Msgs []DecodedMsg `json:"msgs"`
+	// bech32 addresses of the signers required by the messages, in order of appearance
+	Signers       []string           `json:"signers"`
+	Fee           sdk.Coins          `json:"fee"`
+	GasLimit      uint64             `json:"gas_limit,string"`
+	FeePayer      string             `json:"fee_payer,omitempty"`
+	FeeGranter    string             `json:"fee_granter,omitempty"`
+	Memo          string             `json:"memo"`
+	TimeoutHeight uint64             `json:"timeout_height,string"`
+	Signatures    []DecodedSignature `json:"signatures"`
+}
+
+// A message of a decoded transaction
+type DecodedMsg struct {
+	TypeURL string `json:"@type"`
+	// the decoded message, typed according to the codec. Nil for messages unknown to the codec
+	Msg sdk.Msg `json:"-"`
+	// the packed message, only set for protobuf transactions
+	Any *codectypes.Any `json:"-"`
+	// protobuf json encoding of the message
+	JSON json.RawMessage `json:"value"`
+}
+
+// A signature of a decoded transaction
+type DecodedSignature struct {
+	// public key of the signer, which is nil if the public key is already known on chain
+	PubKey cryptotypes.PubKey `json:"-"`
+	// json encoding of the public key, including its `@type`
+	PubKeyJSON json.RawMessage `json:"pub_key,omitempty"`
+	// sequence of the signer, which is not included in amino json signatures
+	Sequence uint64 `json:"sequence,string"`
+	// sign modes of the signature, with multisig signatures listing those of each signature they contain
+	SignModes []string `json:"sign_modes"`
+	// raw signature, which is empty for multisig signatures
+	Signature []byte `json:"signature,omitempty"`
+}
+
+// Decodes the transaction with the codec of the client, rendering signers with the configured account prefix.
+// Messages of binary protobuf transactions which are unknown to the codec are resolved through the node
+func (c *Client) DecodeTx(txBytes []byte) (*DecodedTx, error) {
+	return c.DecodeTxContext(context.Background(), txBytes)
+}
+
+// Decodes the transaction like `DecodeTx`, resolving messages unknown to the codec within the deadline of the context
+func (c *Client) DecodeTxContext(ctx context.Context, txBytes []byte) (*DecodedTx, error) {
+	decoded, err := c.Codec.DecodeTx(txBytes, c.cfg.AccountPrefix)
+	if err == nil || c.dynamicTypes() == nil {
+		return decoded, err
+	}
+	if trimmed := bytes.TrimSpace(txBytes); len(trimmed) > 0 && trimmed[0] == '{' {
+		return nil, err
+	}
+	return c.decodeDynamicTx(ctx, txBytes)
+}
+
+// decodes a binary protobuf transaction including messages unknown to the codec. The remainder of the
+// transaction is decoded by the codec, with the unknown messages decoded into dynamic messages
+func (c *Client) decodeDynamicTx(ctx context.Context, txBytes []byte) (*DecodedTx, error) {
+	var raw txtypes.TxRaw
+	if err := raw.Unmarshal(txBytes); err != nil {
+		return nil, fmt.Errorf("failed to decode transaction %s", err)
+	}
+	var body txtypes.TxBody
+	if err := body.Unmarshal(raw.BodyBytes); err != nil {
+		return nil, fmt.Errorf("failed to decode transaction %s", err)
+	}
+	msgs := body.Messages
+	body.Messages = nil
+	bodyBytes, err := body.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode transaction %s", err)
+	}
+	stripped, err := (&txtypes.TxRaw{BodyBytes: bodyBytes, AuthInfoBytes: raw.AuthInfoBytes, Signatures: raw.Signatures}).Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode transaction %s", err)
+	}
+	decoded, err := c.Codec.decodeProtoTx(stripped)
+	if err != nil {
+		return nil, err
+	}
+	decoded.Hash = fmt.Sprintf("%X", comettypes.Tx(txBytes).Hash())
+	for _, packed := range msgs {
+		msg := DecodedMsg{TypeURL: packed.TypeUrl, Any: packed}
+		var known sdk.Msg
+		if err := c.Codec.InterfaceRegistry.UnpackAny(packed, &known); err == nil {
+			msg.Msg = known
+		}
+		decoded.Msgs = append(decoded.Msgs, msg)
+	}
+	resolve := func(packed *codectypes.Any) (proto.Message, json.RawMessage, error) {
+		msg, err := c.dynamicTypes().Unpack(ctx, packed)
+		if err != nil {
+			return nil, nil, err
+		}
+		bz, err := protojson.MarshalOptions{Resolver: c.dynamicTypes().resolver(ctx)}.Marshal(msg)
+		if err != nil {
+			return nil, nil, err
+		}
+		return msg, bz, nil
+	}
+	if err := c.Codec.decodeMsgs(decoded, c.cfg.AccountPrefix, resolve); err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}
+
+// Decodes the transaction without querying a node, rendering signers with the given bech32 prefix. The
+// transaction may be encoded as binary protobuf, protobuf json, or amino json with or without the
+// `cosmos-sdk/StdTx` type wrapper. Messages must be registered against the codec
+func (cdc Codec) DecodeTx(txBytes []byte, bech32Prefix string) (*DecodedTx, error) {
+	var (
+		decoded *DecodedTx
+		err     error
+	)
+	if trimmed := bytes.TrimSpace(txBytes); len(trimmed) > 0 && trimmed[0] == '{' {
+		decoded, err = cdc.decodeJSONTx(trimmed)
+	} else {
+		decoded, err = cdc.decodeProtoTx(txBytes)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := cdc.decodeMsgs(decoded, bech32Prefix, nil); err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}
+
+// decodes binary protobuf transactions
+func (cdc Codec) decodeProtoTx(txBytes []byte) (*DecodedTx, error) {
+	tx, err := cdc.TxConfig.TxDecoder()(txBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode transaction %s", err)
+	}
+	decoded, err := cdc.fromProtoTx(tx)
+	if err != nil {
+		return nil, err
+	}
+	decoded.Encoding = TxEncodingProto
+	decoded.Hash = fmt.Sprintf("%X", comettypes.Tx(txBytes).Hash())
+	return decoded, nil
+}
+
+// decodes protobuf json and amino json transactions
+func (cdc Codec) decodeJSONTx(txBytes []byte) (*DecodedTx, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(txBytes, &fields); err != nil {
+		return nil, fmt.Errorf("failed to decode transaction %s", err)
+	}
+	if _, ok := fields["body"]; ok {
+		tx, err := cdc.TxConfig.TxJSONDecoder()(txBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode transaction %s", err)
+		}
+		decoded, err := cdc.fromProtoTx(tx)
+		if err != nil {
+			return nil, err
+		}
+		decoded.Encoding = TxEncodingProtoJSON
+		return decoded, nil
+	}
+
+	if _, ok := fields["type"]; !ok {
+		txBytes = []byte(fmt.Sprintf(`{"type":"cosmos-sdk/StdTx","value":%s}`, txBytes))
+	}
+	var stdTx legacytx.StdTx
+	if err := cdc.Amino.UnmarshalJSON(txBytes, &stdTx); err != nil {
+		return nil, fmt.Errorf("failed to decode transaction %s", err)
+	}
+	decoded := &DecodedTx{
+		Encoding:      TxEncodingAminoJSON,
+		Fee:           stdTx.Fee.Amount,
+		GasLimit:      stdTx.Fee.Gas,
+		FeePayer:      stdTx.Fee.Payer,
+		FeeGranter:    stdTx.Fee.Granter,
+		Memo:          stdTx.Memo,
+		TimeoutHeight: stdTx.TimeoutHeight,
+	}
+	for _, msg := range stdTx.Msgs {
+		decoded.Msgs = append(decoded.Msgs, DecodedMsg{TypeURL: sdk.MsgTypeURL(msg), Msg: msg})
+	}
+	if err := cdc.decodeSignatures(decoded, stdTx); err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}
+
+// returns the structured view of a transaction decoded by the tx config
+func (cdc Codec) fromProtoTx(tx sdk.Tx) (*DecodedTx, error) {
+	wrapped, ok := tx.(interface{ GetProtoTx() *txtypes.Tx })
+	if !ok {
+		return nil, fmt.Errorf("unexpected transaction type %T", tx)
+	}
+	protoTx := wrapped.GetProtoTx()
+	decoded := &DecodedTx{
+		Memo:          protoTx.Body.Memo,
+		TimeoutHeight: protoTx.Body.TimeoutHeight,
+	}
+	if fee := protoTx.AuthInfo.Fee; fee != nil {
+		decoded.Fee = fee.Amount
+		decoded.GasLimit = fee.GasLimit
+		decoded.FeePayer = fee.Payer
+		decoded.FeeGranter = fee.Granter
+	}
+	for i, msg := range tx.GetMsgs() {
+		decoded.Msgs = append(decoded.Msgs, DecodedMsg{TypeURL: sdk.MsgTypeURL(msg), Msg: msg, Any: protoTx.Body.Messages[i]})
+	}
+	sigTx, ok := tx.(authsigning.SigVerifiableTx)
+	if !ok {
+		return nil, fmt.Errorf("unexpected transaction type %T", tx)
+	}
+	if err := cdc.decodeSignatures(decoded, sigTx); err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}
+
+// sets the signatures of the transaction
+func (cdc Codec) decodeSignatures(decoded *DecodedTx, tx interface {
+	GetSignaturesV2() ([]signingtypes.SignatureV2, error)
+}) error {
+	sigs, err := tx.GetSignaturesV2()
+	if err != nil {
+		return fmt.Errorf("failed to decode signatures %s", err)
+	}
+	for _, sig := range sigs {
+		decodedSig := DecodedSignature{
+			PubKey:    sig.PubKey,
+			Sequence:  sig.Sequence,
+			SignModes: signatureModes(sig.Data),
+		}
+		if single, ok := sig.Data.(*signingtypes.SingleSignatureData); ok {
+			decodedSig.Signature = single.Signature
+		}
+		if sig.PubKey != nil {
+			packed, err := codectypes.NewAnyWithValue(sig.PubKey)
+			if err != nil {
+				return fmt.Errorf("failed to pack public key %s", err)
+			}
+			if decodedSig.PubKeyJSON, err = cdc.Marshaler.MarshalJSON(packed); err != nil {
+				return fmt.Errorf("failed to encode public key %s", err)
+			}
+		}
+		decoded.Signatures = append(decoded.Signatures, decodedSig)
+	}
+	return nil
+}
+
+// sets the json encoding of the messages, and the signers they require. Messages unknown to the codec are
+// decoded by `resolve`, if given
+func (cdc Codec) decodeMsgs(
+	decoded *DecodedTx,
+	bech32Prefix string,
+	resolve func(packed *codectypes.Any) (proto.Message, json.RawMessage, error),
+) error {
+	addrCodec := authcodec.NewBech32Codec(bech32Prefix)
+	signingCtx, err := signing.NewContext(signing.Options{
+		FileResolver:          gogoproto.HybridResolver,
+		AddressCodec:          addrCodec,
+		ValidatorAddressCodec: authcodec.NewBech32Codec(bech32Prefix + sdk.PrefixValidator + sdk.PrefixOperator),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create signing context %s", err)
+	}
+	seen := make(map[string]bool)
+	for i := range decoded.Msgs {
+		msg := &decoded.Msgs[i]
+		var msgV2 proto.Message
+		if msg.Msg == nil {
+			if resolve == nil || msg.Any == nil {
+				return fmt.Errorf("unknown message %s", msg.TypeURL)
+			}
+			if msgV2, msg.JSON, err = resolve(msg.Any); err != nil {
+				return fmt.Errorf("failed to decode %s %s", msg.TypeURL, err)
+			}
+		} else {
+			if msg.JSON, err = cdc.Marshaler.MarshalJSON(msg.Msg); err != nil {
+				return fmt.Errorf("failed to encode %s %s", msg.TypeURL, err)
+			}
+			value, err := cdc.Marshaler.Marshal(msg.Msg)
+			if err != nil {
+				return fmt.Errorf("failed to encode %s %s", msg.TypeURL, err)
+			}
+			if msgV2, err = anyutil.Unpack(&anypb.Any{TypeUrl: msg.TypeURL, Value: value}, cdc.InterfaceRegistry, nil); err != nil {
+				return fmt.Errorf("failed to decode %s %s", msg.TypeURL, err)
+			}
+		}
+		signers, err := signingCtx.GetSigners(msgV2)
+		if err != nil {
+			return fmt.Errorf("failed to get signers of %s %s", msg.TypeURL, err)
+		}
+		for _, signer := range signers {
+			addr, err := addrCodec.BytesToString(signer)
+			if err != nil {
+				return fmt.Errorf("invalid signer of %s %s", msg.TypeURL, err)
+			}
+			if !seen[addr] {
+				seen[addr] = true
+				decoded.Signers = append(decoded.Signers, addr)
+			}
+		}
+	}
+	return nil
+}
+
+// returns the sign modes of the signature, flattening multisig signatures
+func signatureModes(data signingtypes.SignatureData) []string {
+	switch data := data.(type) {
+	case *signingtypes.SingleSignatureData:
+		return []string{data.SignMode.String()}
+	case *signingtypes.MultiSignatureData:
+		var modes []string
+		for _, sig := range data.Signatures {
+			modes = append(modes, signatureModes(sig)...)
+		}
+		return modes
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,183 @@
+package compass_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	comettypes "github.com/cometbft/cometbft/types"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	"github.com/cosmos/cosmos-sdk/x/auth/migrations/legacytx"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/require"
+	"github.com/teamscanworks/compass"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+func TestDecodeTx(t *testing.T) {
+	cdc := compass.MakeCodec(compass.ModuleBasics, nil)
+	priv := secp256k1.GenPrivKeyFromSecret([]byte("decode"))
+	from := sdk.AccAddress(priv.PubKey().Address())
+	fromAddr := sdk.MustBech32ifyAddressBytes("osmo", from)
+	msg := &banktypes.MsgSend{
+		FromAddress: fromAddr,
+		ToAddress:   sdk.MustBech32ifyAddressBytes("osmo", []byte("recipient_address___")),
+		Amount:      sdk.NewCoins(sdk.NewInt64Coin("uosmo", 10)),
+	}
+	fee := sdk.NewCoins(sdk.NewInt64Coin("uosmo", 2500))
+
+	builder := cdc.TxConfig.NewTxBuilder()
+	require.NoError(t, builder.SetMsgs(msg))
+	builder.SetFeeAmount(fee)
+	builder.SetGasLimit(100000)
+	builder.SetMemo("memo")
+	builder.SetTimeoutHeight(42)
+	require.NoError(t, builder.SetSignatures(signing.SignatureV2{
+		PubKey:   priv.PubKey(),
+		Data:     &signing.SingleSignatureData{SignMode: signing.SignMode_SIGN_MODE_DIRECT, Signature: []byte("signature")},
+		Sequence: 3,
+	}))
+	txBytes, err := cdc.TxConfig.TxEncoder()(builder.GetTx())
+	require.NoError(t, err)
+
+	decoded, err := cdc.DecodeTx(txBytes, "osmo")
+	require.NoError(t, err)
+	require.Equal(t, compass.TxEncodingProto, decoded.Encoding)
+	require.Len(t, decoded.Hash, 64)
+	require.Len(t, decoded.Msgs, 1)
+	require.Equal(t, "/cosmos.bank.v1beta1.MsgSend", decoded.Msgs[0].TypeURL)
+	require.Equal(t, msg, decoded.Msgs[0].Msg)
+	require.JSONEq(t,
+		`{"from_address":"`+msg.FromAddress+`","to_address":"`+msg.ToAddress+`","amount":[{"denom":"uosmo","amount":"10"}]}`,
+		string(decoded.Msgs[0].JSON),
+	)
+	require.Equal(t, []string{fromAddr}, decoded.Signers)
+	require.Equal(t, fee, decoded.Fee)
+	require.Equal(t, uint64(100000), decoded.GasLimit)
+	require.Equal(t, "memo", decoded.Memo)
+	require.Equal(t, uint64(42), decoded.TimeoutHeight)
+	require.Len(t, decoded.Signatures, 1)
+	require.Equal(t, priv.PubKey(), decoded.Signatures[0].PubKey)
+	require.Equal(t, uint64(3), decoded.Signatures[0].Sequence)
+	require.Equal(t, []string{"SIGN_MODE_DIRECT"}, decoded.Signatures[0].SignModes)
+	require.Equal(t, []byte("signature"), decoded.Signatures[0].Signature)
+
+	rendered, err := json.Marshal(decoded)
+	require.NoError(t, err)
+	require.Contains(t, string(rendered), `"@type":"/cosmos.crypto.secp256k1.PubKey"`)
+
+	// protobuf json
+	jsonBytes, err := cdc.TxConfig.TxJSONEncoder()(builder.GetTx())
+	require.NoError(t, err)
+	fromJSON, err := cdc.DecodeTx(jsonBytes, "osmo")
+	require.NoError(t, err)
+	require.Equal(t, compass.TxEncodingProtoJSON, fromJSON.Encoding)
+	require.Empty(t, fromJSON.Hash)
+	fromJSON.Encoding, fromJSON.Hash = decoded.Encoding, decoded.Hash
+	require.Equal(t, decoded, fromJSON)
+
+	_, err = cdc.DecodeTx([]byte("not a transaction"), "osmo")
+	require.Error(t, err)
+}
+
+func TestDecodeTxAminoJSON(t *testing.T) {
+	cdc := compass.MakeCodec(compass.ModuleBasics, nil)
+	priv := secp256k1.GenPrivKeyFromSecret([]byte("decode"))
+	from := sdk.AccAddress(priv.PubKey().Address())
+	msg := &banktypes.MsgSend{
+		FromAddress: sdk.MustBech32ifyAddressBytes("cosmos", from),
+		ToAddress:   sdk.MustBech32ifyAddressBytes("cosmos", []byte("recipient_address___")),
+		Amount:      sdk.NewCoins(sdk.NewInt64Coin("uatom", 10)),
+	}
+	stdTx := legacytx.NewStdTx(
+		[]sdk.Msg{msg},
+		legacytx.NewStdFee(200000, sdk.NewCoins(sdk.NewInt64Coin("uatom", 500))),
+		[]legacytx.StdSignature{legacytx.NewStdSignature(priv.PubKey(), []byte("signature"))},
+		"amino",
+	)
+	wrapped, err := cdc.Amino.MarshalJSON(stdTx)
+	require.NoError(t, err)
+	var fields struct {
+		Value json.RawMessage `json:"value"`
+	}
+	require.NoError(t, json.Unmarshal(wrapped, &fields))
+
+	for _, txBytes := range [][]byte{wrapped, fields.Value} {
+		decoded, err := cdc.DecodeTx(txBytes, "cosmos")
+		require.NoError(t, err)
+		require.Equal(t, compass.TxEncodingAminoJSON, decoded.Encoding)
+		require.Len(t, decoded.Msgs, 1)
+		require.Equal(t, msg, decoded.Msgs[0].Msg)
+		require.Equal(t, []string{msg.FromAddress}, decoded.Signers)
+		require.Equal(t, uint64(200000), decoded.GasLimit)
+		require.Equal(t, "amino", decoded.Memo)
+		require.Len(t, decoded.Signatures, 1)
+		require.Equal(t, []string{"SIGN_MODE_LEGACY_AMINO_JSON"}, decoded.Signatures[0].SignModes)
+		require.Equal(t, priv.PubKey(), decoded.Signatures[0].PubKey)
+	}
+}
+
+// returns a transaction signed at sequence 5 including a message of a module unknown to compass
+func unknownMsgTx(t *testing.T, file protoreflect.FileDescriptor, signer string, pubKey cryptotypes.PubKey) []byte {
+	custom := dynamicpb.NewMessage(file.Messages().ByName("MsgCustom"))
+	custom.Set(custom.Descriptor().Fields().ByName("signer"), protoreflect.ValueOfString(signer))
+	custom.Set(custom.Descriptor().Fields().ByName("amount"), protoreflect.ValueOfUint64(5))
+	value, err := proto.Marshal(custom)
+	require.NoError(t, err)
+	body, err := (&txtypes.TxBody{
+		Messages: []*codectypes.Any{{TypeUrl: "/compass.test.v1.MsgCustom", Value: value}},
+		Memo:     "unknown",
+	}).Marshal()
+	require.NoError(t, err)
+	packedKey, err := codectypes.NewAnyWithValue(pubKey)
+	require.NoError(t, err)
+	authInfo, err := (&txtypes.AuthInfo{
+		SignerInfos: []*txtypes.SignerInfo{{
+			PublicKey: packedKey,
+			ModeInfo:  &txtypes.ModeInfo{Sum: &txtypes.ModeInfo_Single_{Single: &txtypes.ModeInfo_Single{Mode: signing.SignMode_SIGN_MODE_DIRECT}}},
+			Sequence:  5,
+		}},
+		Fee: &txtypes.Fee{Amount: sdk.NewCoins(sdk.NewInt64Coin("stake", 10)), GasLimit: 100_000},
+	}).Marshal()
+	require.NoError(t, err)
+	txBytes, err := (&txtypes.TxRaw{BodyBytes: body, AuthInfoBytes: authInfo, Signatures: [][]byte{[]byte("signature")}}).Marshal()
+	require.NoError(t, err)
+	return txBytes
+}
+
+func TestDecodeTxUnknownMsgs(t *testing.T) {
+	var file protoreflect.FileDescriptor
+	client, _ := newJournaledClient(t, func(srv *grpc.Server) { file = registerReflection(t, srv) })
+	signer := client.FromAddress()
+	record, err := client.Keyring.Key("default")
+	require.NoError(t, err)
+	pubKey, err := record.GetPubKey()
+	require.NoError(t, err)
+	txBytes := unknownMsgTx(t, file, signer, pubKey)
+
+	// unknown messages are resolved through the node
+	_, err = client.Codec.DecodeTx(txBytes, "cosmos")
+	require.Error(t, err)
+	decoded, err := client.DecodeTxContext(context.Background(), txBytes)
+	require.NoError(t, err)
+	require.Equal(t, fmt.Sprintf("%X", comettypes.Tx(txBytes).Hash()), decoded.Hash)
+	require.Len(t, decoded.Msgs, 1)
+	require.Equal(t, "/compass.test.v1.MsgCustom", decoded.Msgs[0].TypeURL)
+	require.Nil(t, decoded.Msgs[0].Msg)
+	require.NotNil(t, decoded.Msgs[0].Any)
+	require.JSONEq(t, `{"signer":"`+signer+`","amount":"5"}`, string(decoded.Msgs[0].JSON))
+	require.Equal(t, []string{signer}, decoded.Signers)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 10)), decoded.Fee)
+	require.Equal(t, "unknown", decoded.Memo)
+	require.Equal(t, uint64(5), decoded.Signatures[0].Sequence)
+	require.Equal(t, pubKey, decoded.Signatures[0].PubKey)
+}
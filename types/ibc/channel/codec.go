@@ -0,0 +1,55 @@
+// Package channel provides the protobuf types of the ibc-go 04-channel module, which can not be
+// imported alongside the version of the cosmos-sdk used by compass
+package channel
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/msgservice"
+)
+
+// Registers the channel handshake and packet messages against the interface registry
+func RegisterInterfaces(registry types.InterfaceRegistry) {
+	registry.RegisterImplementations((*sdk.Msg)(nil),
+		&MsgChannelOpenInit{},
+		&MsgChannelOpenTry{},
+		&MsgChannelOpenAck{},
+		&MsgChannelOpenConfirm{},
+		&MsgChannelCloseInit{},
+		&MsgChannelCloseConfirm{},
+		&MsgRecvPacket{},
+		&MsgAcknowledgement{},
+		&MsgTimeout{},
+		&MsgTimeoutOnClose{},
+	)
+	msgservice.RegisterMsgServiceDesc(registry, &_Msg_serviceDesc)
+}
+
+// Parses an acknowledgement written by an ibc application, which is the json encoding
+// of `Acknowledgement` (ie: `{"result":"AQ=="}` or `{"error":"..."}`)
+func ParseAcknowledgement(ack []byte) (*Acknowledgement, error) {
+	var raw struct {
+		Result []byte  `json:"result"`
+		Error  *string `json:"error"`
+	}
+	if err := json.Unmarshal(ack, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse acknowledgement %s", err)
+	}
+	switch {
+	case raw.Error != nil:
+		return &Acknowledgement{Response: &Acknowledgement_Error{Error: *raw.Error}}, nil
+	case raw.Result != nil:
+		return &Acknowledgement{Response: &Acknowledgement_Result{Result: raw.Result}}, nil
+	default:
+		return nil, fmt.Errorf("acknowledgement has neither a result nor an error")
+	}
+}
+
+// Returns true if the acknowledgement indicates the packet was successfully processed
+func (ack *Acknowledgement) Success() bool {
+	_, ok := ack.Response.(*Acknowledgement_Result)
+	return ok
+}
@@ -0,0 +1,83 @@
+// Package client provides the protobuf types of the ibc-go 02-client module, which can not be
+// imported alongside the version of the cosmos-sdk used by compass
+package client
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/msgservice"
+)
+
+// matches chain ids of the format {chain-name}-{revision-number}
+var revisionFormat = regexp.MustCompile(`^.*[^\n-]-{1}[1-9][0-9]*$`)
+
+// Registers the client messages against the interface registry
+func RegisterInterfaces(registry types.InterfaceRegistry) {
+	registry.RegisterImplementations((*sdk.Msg)(nil),
+		&MsgCreateClient{},
+		&MsgUpdateClient{},
+		&MsgUpgradeClient{},
+		&MsgSubmitMisbehaviour{},
+	)
+	msgservice.RegisterMsgServiceDesc(registry, &_Msg_serviceDesc)
+}
+
+// Returns a new height
+func NewHeight(revisionNumber, revisionHeight uint64) Height {
+	return Height{RevisionNumber: revisionNumber, RevisionHeight: revisionHeight}
+}
+
+// Returns the height formatted as `{revision-number}-{revision-height}`
+func (h Height) String() string {
+	return fmt.Sprintf("%d-%d", h.RevisionNumber, h.RevisionHeight)
+}
+
+// Returns true if both the revision number and height are zero
+func (h Height) IsZero() bool {
+	return h.RevisionNumber == 0 && h.RevisionHeight == 0
+}
+
+// Returns true if the height is greater than or equal to the other height
+func (h Height) GTE(other Height) bool {
+	if h.RevisionNumber != other.RevisionNumber {
+		return h.RevisionNumber > other.RevisionNumber
+	}
+	return h.RevisionHeight >= other.RevisionHeight
+}
+
+// Returns the revision number encoded in the chain id, following the ibc convention
+// of `{chain-name}-{revision-number}`. Chain ids which do not follow this format have
+// a revision number of 0
+func ParseChainID(chainID string) uint64 {
+	if !revisionFormat.MatchString(chainID) {
+		return 0
+	}
+	idx := strings.LastIndex(chainID, "-")
+	revision, err := strconv.ParseUint(chainID[idx+1:], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return revision
+}
+
+// Parses a height formatted as `{revision-number}-{revision-height}`
+func ParseHeight(height string) (Height, error) {
+	number, blockHeight, ok := strings.Cut(height, "-")
+	if !ok {
+		return Height{}, fmt.Errorf("invalid height %q", height)
+	}
+	revisionNumber, err := strconv.ParseUint(number, 10, 64)
+	if err != nil {
+		return Height{}, fmt.Errorf("invalid revision number %q", number)
+	}
+	revisionHeight, err := strconv.ParseUint(blockHeight, 10, 64)
+	if err != nil {
+		return Height{}, fmt.Errorf("invalid revision height %q", blockHeight)
+	}
+	return NewHeight(revisionNumber, revisionHeight), nil
+}
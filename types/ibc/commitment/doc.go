@@ -0,0 +1,3 @@
+// Package commitment provides the protobuf types of the ibc-go 23-commitment module, which can not be
+// imported alongside the version of the cosmos-sdk used by compass
+package commitment
@@ -0,0 +1,20 @@
+// Package connection provides the protobuf types of the ibc-go 03-connection module, which can not be
+// imported alongside the version of the cosmos-sdk used by compass
+package connection
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/msgservice"
+)
+
+// Registers the connection handshake messages against the interface registry
+func RegisterInterfaces(registry types.InterfaceRegistry) {
+	registry.RegisterImplementations((*sdk.Msg)(nil),
+		&MsgConnectionOpenInit{},
+		&MsgConnectionOpenTry{},
+		&MsgConnectionOpenAck{},
+		&MsgConnectionOpenConfirm{},
+	)
+	msgservice.RegisterMsgServiceDesc(registry, &_Msg_serviceDesc)
+}
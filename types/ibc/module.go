@@ -0,0 +1,44 @@
+// Package ibc registers the ibc-go core and ICS-20 transfer types, which can not be imported
+// alongside the version of the cosmos-sdk used by compass. Light client types are not included, so
+// client states, consensus states and headers contained in client messages remain packed
+package ibc
+
+import (
+	sdkclient "github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/types/module"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/spf13/cobra"
+	"github.com/teamscanworks/compass/types/ibc/channel"
+	"github.com/teamscanworks/compass/types/ibc/client"
+	"github.com/teamscanworks/compass/types/ibc/connection"
+	"github.com/teamscanworks/compass/types/ibc/transfer"
+)
+
+const ModuleName = "ibc"
+
+var _ module.AppModuleBasic = AppModuleBasic{}
+
+// AppModuleBasic registers the ibc core and transfer types for encoding and decoding, it does not
+// provide any client functionality
+type AppModuleBasic struct{}
+
+func (AppModuleBasic) Name() string { return ModuleName }
+
+func (AppModuleBasic) RegisterLegacyAminoCodec(cdc *codec.LegacyAmino) {
+	transfer.RegisterLegacyAminoCodec(cdc)
+}
+
+func (AppModuleBasic) RegisterInterfaces(registry types.InterfaceRegistry) {
+	client.RegisterInterfaces(registry)
+	connection.RegisterInterfaces(registry)
+	channel.RegisterInterfaces(registry)
+	transfer.RegisterInterfaces(registry)
+}
+
+func (AppModuleBasic) RegisterGRPCGatewayRoutes(sdkclient.Context, *runtime.ServeMux) {}
+
+func (AppModuleBasic) GetTxCmd() *cobra.Command { return nil }
+
+func (AppModuleBasic) GetQueryCmd() *cobra.Command { return nil }
@@ -0,0 +1,34 @@
+// Package concentratedliquidity provides the protobuf types of the osmosis x/concentrated-liquidity module,
+// which can not be imported alongside the version of the cosmos-sdk used by compass
+package concentratedliquidity
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/codec/legacy"
+	"github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/msgservice"
+)
+
+const ModuleName = "concentratedliquidity"
+
+// Registers the concentrated liquidity messages against the amino codec
+func RegisterLegacyAminoCodec(cdc *codec.LegacyAmino) {
+	legacy.RegisterAminoMsg(cdc, &MsgCreatePosition{}, "osmosis/cl-create-position")
+	legacy.RegisterAminoMsg(cdc, &MsgAddToPosition{}, "osmosis/cl-add-to-position")
+	legacy.RegisterAminoMsg(cdc, &MsgWithdrawPosition{}, "osmosis/cl-withdraw-position")
+	legacy.RegisterAminoMsg(cdc, &MsgCollectSpreadRewards{}, "osmosis/cl-col-sp-rewards")
+	legacy.RegisterAminoMsg(cdc, &MsgCollectIncentives{}, "osmosis/cl-collect-incentives")
+}
+
+// Registers the concentrated liquidity messages against the interface registry
+func RegisterInterfaces(registry types.InterfaceRegistry) {
+	registry.RegisterImplementations((*sdk.Msg)(nil),
+		&MsgCreatePosition{},
+		&MsgAddToPosition{},
+		&MsgWithdrawPosition{},
+		&MsgCollectSpreadRewards{},
+		&MsgCollectIncentives{},
+	)
+	msgservice.RegisterMsgServiceDesc(registry, &_Msg_serviceDesc)
+}
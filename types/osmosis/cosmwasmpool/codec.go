@@ -0,0 +1,8 @@
+// Package cosmwasmpool provides the protobuf types of the osmosis x/cosmwasmpool module, which can not be
+// imported alongside the version of the cosmos-sdk used by compass
+package cosmwasmpool
+
+// Returns the id of the pool
+func (p *CosmWasmPool) GetId() uint64 {
+	return p.GetPoolId()
+}
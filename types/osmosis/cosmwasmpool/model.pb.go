@@ -0,0 +1,462 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: osmosis/cosmwasmpool/v1beta1/model.proto
+
+package cosmwasmpool
+
+import (
+	fmt "fmt"
+	proto "github.com/cosmos/gogoproto/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// CosmWasmPool represents the data serialized into state for each CW pool.
+//
+// Note: CW Pool has 2 pool models:
+// - CosmWasmPool which is a proto-generated store model used for serialization
+// into state.
+// - Pool struct that encapsulates the CosmWasmPool and wasmKeeper for calling
+// the contract.
+//
+// CosmWasmPool implements the poolmanager.PoolI interface but it panics on all
+// methods. The reason is that access to wasmKeeper is required to call the
+// contract.
+//
+// Instead, all interactions and poolmanager.PoolI methods are to be performed
+// on the Pool struct. The reason why we cannot have a Pool struct only is
+// because it cannot be serialized into state due to having a non-serializable
+// wasmKeeper field.
+type CosmWasmPool struct {
+	ContractAddress string `protobuf:"bytes,1,opt,name=contract_address,json=contractAddress,proto3" json:"contract_address,omitempty"`
+	PoolId          uint64 `protobuf:"varint,2,opt,name=pool_id,json=poolId,proto3" json:"pool_id,omitempty"`
+	CodeId          uint64 `protobuf:"varint,3,opt,name=code_id,json=codeId,proto3" json:"code_id,omitempty"`
+	InstantiateMsg  []byte `protobuf:"bytes,4,opt,name=instantiate_msg,json=instantiateMsg,proto3" json:"instantiate_msg,omitempty"`
+}
+
+func (m *CosmWasmPool) Reset()         { *m = CosmWasmPool{} }
+func (m *CosmWasmPool) String() string { return proto.CompactTextString(m) }
+func (*CosmWasmPool) ProtoMessage()    {}
+func (*CosmWasmPool) Descriptor() ([]byte, []int) {
+	return fileDescriptor_317100e4262ccda2, []int{0}
+}
+func (m *CosmWasmPool) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CosmWasmPool) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CosmWasmPool.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CosmWasmPool) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CosmWasmPool.Merge(m, src)
+}
+func (m *CosmWasmPool) XXX_Size() int {
+	return m.Size()
+}
+func (m *CosmWasmPool) XXX_DiscardUnknown() {
+	xxx_messageInfo_CosmWasmPool.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CosmWasmPool proto.InternalMessageInfo
+
+func (m *CosmWasmPool) GetContractAddress() string {
+	if m != nil {
+		return m.ContractAddress
+	}
+	return ""
+}
+
+func (m *CosmWasmPool) GetPoolId() uint64 {
+	if m != nil {
+		return m.PoolId
+	}
+	return 0
+}
+
+func (m *CosmWasmPool) GetCodeId() uint64 {
+	if m != nil {
+		return m.CodeId
+	}
+	return 0
+}
+
+func (m *CosmWasmPool) GetInstantiateMsg() []byte {
+	if m != nil {
+		return m.InstantiateMsg
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*CosmWasmPool)(nil), "osmosis.cosmwasmpool.v1beta1.CosmWasmPool")
+}
+
+func init() {
+	proto.RegisterFile("osmosis/cosmwasmpool/v1beta1/model.proto", fileDescriptor_317100e4262ccda2)
+}
+
+var fileDescriptor_317100e4262ccda2 = []byte{
+	// 260 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x90, 0xb1, 0x4a, 0xfc, 0x40,
+	0x10, 0x87, 0xb3, 0xff, 0xff, 0x71, 0x62, 0x38, 0x3c, 0x49, 0x63, 0x0a, 0x59, 0x82, 0x8d, 0xb1,
+	0xc9, 0x72, 0x58, 0x5a, 0xa9, 0xd5, 0x15, 0x82, 0x04, 0x44, 0xb0, 0x09, 0x9b, 0xdd, 0x25, 0x06,
+	0x33, 0x99, 0x90, 0x19, 0x3d, 0x7c, 0x0b, 0x0b, 0x1f, 0xca, 0xf2, 0x4a, 0x4b, 0x49, 0x5e, 0x44,
+	0xd6, 0x3b, 0xe1, 0x0a, 0xcb, 0xf9, 0x7d, 0x1f, 0x53, 0x7c, 0x61, 0x8a, 0x04, 0x48, 0x35, 0x29,
+	0x83, 0x04, 0x2b, 0x4d, 0xd0, 0x21, 0x36, 0xea, 0x65, 0x51, 0x3a, 0xd6, 0x0b, 0x05, 0x68, 0x5d,
+	0x93, 0x75, 0x3d, 0x32, 0x46, 0xc7, 0x5b, 0x33, 0xdb, 0x35, 0xb3, 0xad, 0x79, 0xf2, 0x2e, 0xc2,
+	0xd9, 0x35, 0x12, 0xdc, 0x6b, 0x82, 0x5b, 0xc4, 0x26, 0x3a, 0x0b, 0x0f, 0x0d, 0xb6, 0xdc, 0x6b,
+	0xc3, 0x85, 0xb6, 0xb6, 0x77, 0x44, 0xb1, 0x48, 0x44, 0xba, 0x9f, 0xcf, 0x7f, 0xf7, 0xcb, 0xcd,
+	0x1c, 0x1d, 0x85, 0x7b, 0xfe, 0x57, 0x51, 0xdb, 0xf8, 0x5f, 0x22, 0xd2, 0x49, 0x3e, 0xf5, 0xe7,
+	0xd2, 0x7a, 0x60, 0xd0, 0x3a, 0x0f, 0xfe, 0x6f, 0x80, 0x3f, 0x97, 0x36, 0x3a, 0x0d, 0xe7, 0x75,
+	0x4b, 0xac, 0x5b, 0xae, 0x35, 0xbb, 0x02, 0xa8, 0x8a, 0x27, 0x89, 0x48, 0x67, 0xf9, 0xc1, 0xce,
+	0x7c, 0x43, 0xd5, 0xd5, 0xdd, 0xc7, 0x20, 0xc5, 0x7a, 0x90, 0xe2, 0x6b, 0x90, 0xe2, 0x6d, 0x94,
+	0xc1, 0x7a, 0x94, 0xc1, 0xe7, 0x28, 0x83, 0x87, 0x8b, 0xaa, 0xe6, 0xc7, 0xe7, 0x32, 0x33, 0x08,
+	0x8a, 0x9d, 0x06, 0x32, 0xba, 0x5d, 0x61, 0xff, 0xe4, 0x4b, 0x40, 0xa7, 0x89, 0x14, 0xbf, 0x76,
+	0x8e, 0xd4, 0x5f, 0x7d, 0xca, 0xe9, 0x4f, 0x92, 0xf3, 0xef, 0x01, 0x00, 0x34, 0xef, 0x61, 0xc0,
+	0x3e, 0x01, 0x00, 0x00,
+}
+
+func (m *CosmWasmPool) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CosmWasmPool) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CosmWasmPool) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.InstantiateMsg) > 0 {
+		i -= len(m.InstantiateMsg)
+		copy(dAtA[i:], m.InstantiateMsg)
+		i = encodeVarintModel(dAtA, i, uint64(len(m.InstantiateMsg)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.CodeId != 0 {
+		i = encodeVarintModel(dAtA, i, uint64(m.CodeId))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.PoolId != 0 {
+		i = encodeVarintModel(dAtA, i, uint64(m.PoolId))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.ContractAddress) > 0 {
+		i -= len(m.ContractAddress)
+		copy(dAtA[i:], m.ContractAddress)
+		i = encodeVarintModel(dAtA, i, uint64(len(m.ContractAddress)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintModel(dAtA []byte, offset int, v uint64) int {
+	offset -= sovModel(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *CosmWasmPool) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ContractAddress)
+	if l > 0 {
+		n += 1 + l + sovModel(uint64(l))
+	}
+	if m.PoolId != 0 {
+		n += 1 + sovModel(uint64(m.PoolId))
+	}
+	if m.CodeId != 0 {
+		n += 1 + sovModel(uint64(m.CodeId))
+	}
+	l = len(m.InstantiateMsg)
+	if l > 0 {
+		n += 1 + l + sovModel(uint64(l))
+	}
+	return n
+}
+
+func sovModel(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozModel(x uint64) (n int) {
+	return sovModel(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *CosmWasmPool) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowModel
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CosmWasmPool: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CosmWasmPool: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContractAddress", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowModel
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthModel
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthModel
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ContractAddress = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PoolId", wireType)
+			}
+			m.PoolId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowModel
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PoolId |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CodeId", wireType)
+			}
+			m.CodeId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowModel
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CodeId |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InstantiateMsg", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowModel
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthModel
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthModel
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.InstantiateMsg = append(m.InstantiateMsg[:0], dAtA[iNdEx:postIndex]...)
+			if m.InstantiateMsg == nil {
+				m.InstantiateMsg = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipModel(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthModel
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipModel(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowModel
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowModel
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowModel
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthModel
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupModel
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthModel
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthModel        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowModel          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupModel = fmt.Errorf("proto: unexpected end of group")
+)
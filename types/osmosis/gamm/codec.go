@@ -0,0 +1,41 @@
+// Package gamm provides the protobuf types of the osmosis x/gamm module, which can not be
+// imported alongside the version of the cosmos-sdk used by compass
+package gamm
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/codec/legacy"
+	"github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/msgservice"
+)
+
+const ModuleName = "gamm"
+
+// Registers the gamm messages against the amino codec
+func RegisterLegacyAminoCodec(cdc *codec.LegacyAmino) {
+	legacy.RegisterAminoMsg(cdc, &MsgJoinPool{}, "osmosis/gamm/join-pool")
+	legacy.RegisterAminoMsg(cdc, &MsgExitPool{}, "osmosis/gamm/exit-pool")
+	legacy.RegisterAminoMsg(cdc, &MsgSwapExactAmountIn{}, "osmosis/gamm/swap-exact-amount-in")
+	legacy.RegisterAminoMsg(cdc, &MsgSwapExactAmountOut{}, "osmosis/gamm/swap-exact-amount-out")
+	legacy.RegisterAminoMsg(cdc, &MsgJoinSwapExternAmountIn{}, "osmosis/gamm/join-swap-extern-amount-in")
+	legacy.RegisterAminoMsg(cdc, &MsgJoinSwapShareAmountOut{}, "osmosis/gamm/join-swap-share-amount-out")
+	// registered directly as the name exceeds the length enforced by RegisterAminoMsg
+	cdc.RegisterConcrete(&MsgExitSwapExternAmountOut{}, "osmosis/gamm/exit-swap-extern-amount-out", nil)
+	legacy.RegisterAminoMsg(cdc, &MsgExitSwapShareAmountIn{}, "osmosis/gamm/exit-swap-share-amount-in")
+}
+
+// Registers the gamm messages against the interface registry
+func RegisterInterfaces(registry types.InterfaceRegistry) {
+	registry.RegisterImplementations((*sdk.Msg)(nil),
+		&MsgJoinPool{},
+		&MsgExitPool{},
+		&MsgSwapExactAmountIn{},
+		&MsgSwapExactAmountOut{},
+		&MsgJoinSwapExternAmountIn{},
+		&MsgJoinSwapShareAmountOut{},
+		&MsgExitSwapExternAmountOut{},
+		&MsgExitSwapShareAmountIn{},
+	)
+	msgservice.RegisterMsgServiceDesc(registry, &_Msg_serviceDesc)
+}
@@ -0,0 +1,37 @@
+// Package lockup provides the protobuf types of the osmosis x/lockup module, which can not be
+// imported alongside the version of the cosmos-sdk used by compass
+package lockup
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/codec/legacy"
+	"github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/msgservice"
+)
+
+const ModuleName = "lockup"
+
+// Registers the lockup messages against the amino codec
+func RegisterLegacyAminoCodec(cdc *codec.LegacyAmino) {
+	legacy.RegisterAminoMsg(cdc, &MsgLockTokens{}, "osmosis/lockup/lock-tokens")
+	legacy.RegisterAminoMsg(cdc, &MsgBeginUnlockingAll{}, "osmosis/lockup/begin-unlock-tokens")
+	legacy.RegisterAminoMsg(cdc, &MsgBeginUnlocking{}, "osmosis/lockup/begin-unlock-period-lock")
+	legacy.RegisterAminoMsg(cdc, &MsgExtendLockup{}, "osmosis/lockup/extend-lockup")
+	legacy.RegisterAminoMsg(cdc, &MsgForceUnlock{}, "osmosis/lockup/force-unlock-tokens")
+	// registered directly as the name exceeds the length enforced by RegisterAminoMsg
+	cdc.RegisterConcrete(&MsgSetRewardReceiverAddress{}, "osmosis/lockup/set-reward-receiver-address", nil)
+}
+
+// Registers the lockup messages against the interface registry
+func RegisterInterfaces(registry types.InterfaceRegistry) {
+	registry.RegisterImplementations((*sdk.Msg)(nil),
+		&MsgLockTokens{},
+		&MsgBeginUnlockingAll{},
+		&MsgBeginUnlocking{},
+		&MsgExtendLockup{},
+		&MsgForceUnlock{},
+		&MsgSetRewardReceiverAddress{},
+	)
+	msgservice.RegisterMsgServiceDesc(registry, &_Msg_serviceDesc)
+}
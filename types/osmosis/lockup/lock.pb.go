@@ -0,0 +1,606 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: osmosis/lockup/lock.proto
+
+package lockup
+
+import (
+	fmt "fmt"
+	github_com_cosmos_cosmos_sdk_types "github.com/cosmos/cosmos-sdk/types"
+	types "github.com/cosmos/cosmos-sdk/types"
+	_ "github.com/cosmos/gogoproto/gogoproto"
+	proto "github.com/cosmos/gogoproto/proto"
+	github_com_cosmos_gogoproto_types "github.com/cosmos/gogoproto/types"
+	_ "google.golang.org/protobuf/types/known/durationpb"
+	_ "google.golang.org/protobuf/types/known/timestamppb"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+	time "time"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+var _ = time.Kitchen
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// PeriodLock is a single lock unit by period defined by the x/lockup module.
+// It's a record of a locked coin at a specific time. It stores owner, duration,
+// unlock time and the number of coins locked. A state of a period lock is
+// created upon lock creation, and deleted once the lock has been matured after
+// the `duration` has passed since unbonding started.
+type PeriodLock struct {
+	// ID is the unique id of the lock.
+	// The ID of the lock is decided upon lock creation, incrementing by 1 for
+	// every lock.
+	ID uint64 `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	// Owner is the account address of the lock owner.
+	// Only the owner can modify the state of the lock.
+	Owner string `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	// Duration is the time needed for a lock to mature after unlocking has
+	// started.
+	Duration time.Duration `protobuf:"bytes,3,opt,name=duration,proto3,stdduration" json:"duration"`
+	// EndTime refers to the time at which the lock would mature and get deleted.
+	// This value is first initialized when an unlock has started for the lock,
+	// end time being block time + duration.
+	EndTime time.Time `protobuf:"bytes,4,opt,name=end_time,json=endTime,proto3,stdtime" json:"end_time"`
+	// Coins are the tokens locked within the lock, kept in the module account.
+	Coins github_com_cosmos_cosmos_sdk_types.Coins `protobuf:"bytes,5,rep,name=coins,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins" json:"coins"`
+	// Reward Receiver Address is the address that would be receiving rewards for
+	// the incentives for the lock. This is set to owner by default and can be
+	// changed via separate msg.
+	RewardReceiverAddress string `protobuf:"bytes,6,opt,name=reward_receiver_address,json=rewardReceiverAddress,proto3" json:"reward_receiver_address,omitempty"`
+}
+
+func (m *PeriodLock) Reset()         { *m = PeriodLock{} }
+func (m *PeriodLock) String() string { return proto.CompactTextString(m) }
+func (*PeriodLock) ProtoMessage()    {}
+func (*PeriodLock) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7e9d7527a237b489, []int{0}
+}
+func (m *PeriodLock) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PeriodLock) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PeriodLock.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PeriodLock) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeriodLock.Merge(m, src)
+}
+func (m *PeriodLock) XXX_Size() int {
+	return m.Size()
+}
+func (m *PeriodLock) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeriodLock.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeriodLock proto.InternalMessageInfo
+
+func (m *PeriodLock) GetID() uint64 {
+	if m != nil {
+		return m.ID
+	}
+	return 0
+}
+
+func (m *PeriodLock) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *PeriodLock) GetDuration() time.Duration {
+	if m != nil {
+		return m.Duration
+	}
+	return 0
+}
+
+func (m *PeriodLock) GetEndTime() time.Time {
+	if m != nil {
+		return m.EndTime
+	}
+	return time.Time{}
+}
+
+func (m *PeriodLock) GetCoins() github_com_cosmos_cosmos_sdk_types.Coins {
+	if m != nil {
+		return m.Coins
+	}
+	return nil
+}
+
+func (m *PeriodLock) GetRewardReceiverAddress() string {
+	if m != nil {
+		return m.RewardReceiverAddress
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*PeriodLock)(nil), "osmosis.lockup.PeriodLock")
+}
+
+func init() { proto.RegisterFile("osmosis/lockup/lock.proto", fileDescriptor_7e9d7527a237b489) }
+
+var fileDescriptor_7e9d7527a237b489 = []byte{
+	// 393 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x92, 0x31, 0x6f, 0xd4, 0x30,
+	0x14, 0xc7, 0xe3, 0xb4, 0x57, 0x8a, 0x91, 0x3a, 0x44, 0x45, 0xa4, 0x37, 0x24, 0x11, 0x53, 0x16,
+	0x6c, 0x0a, 0x82, 0x15, 0x71, 0xdc, 0x52, 0x09, 0x09, 0x14, 0x31, 0xb1, 0x9c, 0x9c, 0xd8, 0x04,
+	0x2b, 0x4d, 0x5e, 0x64, 0x3b, 0x3d, 0xf1, 0x2d, 0x3a, 0xf2, 0x19, 0xf8, 0x24, 0xb7, 0x71, 0x23,
+	0x13, 0x87, 0xee, 0xbe, 0x08, 0xb2, 0x9d, 0x20, 0xa0, 0x93, 0xe3, 0xfc, 0xdf, 0xfb, 0xbd, 0xff,
+	0xff, 0xc9, 0xf8, 0x02, 0x74, 0x0b, 0x5a, 0x6a, 0x7a, 0x0d, 0x55, 0x33, 0xf4, 0xee, 0x20, 0xbd,
+	0x02, 0x03, 0xd1, 0xd9, 0x28, 0x11, 0x2f, 0xcd, 0xcf, 0x6b, 0xa8, 0xc1, 0x49, 0xd4, 0x7e, 0xf9,
+	0xaa, 0x79, 0x52, 0x03, 0xd4, 0xd7, 0x82, 0xba, 0x5b, 0x39, 0x7c, 0xa2, 0x7c, 0x50, 0xcc, 0x48,
+	0xe8, 0x46, 0x3d, 0xfd, 0x5f, 0x37, 0xb2, 0x15, 0xda, 0xb0, 0xb6, 0x9f, 0x00, 0x95, 0x9b, 0x43,
+	0x4b, 0xa6, 0x05, 0xbd, 0xb9, 0x2c, 0x85, 0x61, 0x97, 0xb4, 0x02, 0x39, 0x02, 0x1e, 0x7f, 0x0f,
+	0x31, 0x7e, 0x2f, 0x94, 0x04, 0xfe, 0x16, 0xaa, 0x26, 0x3a, 0xc3, 0xe1, 0xd5, 0x32, 0x46, 0x19,
+	0xca, 0x8f, 0x8b, 0xf0, 0x6a, 0x19, 0x9d, 0xe3, 0x19, 0xac, 0x3b, 0xa1, 0xe2, 0x30, 0x43, 0xf9,
+	0xfd, 0xc2, 0x5f, 0xa2, 0x57, 0xf8, 0x74, 0xf2, 0x11, 0x1f, 0x65, 0x28, 0x7f, 0xf0, 0xec, 0x82,
+	0x78, 0x23, 0x64, 0x32, 0x42, 0x96, 0x63, 0xc1, 0xe2, 0x74, 0xf3, 0x33, 0x0d, 0xbe, 0xee, 0x52,
+	0x54, 0xfc, 0x69, 0xb2, 0x00, 0xd1, 0xf1, 0x95, 0x35, 0x1b, 0x1f, 0x3b, 0xc0, 0xfc, 0x0e, 0xe0,
+	0xc3, 0x94, 0xc4, 0x13, 0x6e, 0x2d, 0xe1, 0x9e, 0xe8, 0xb8, 0xfd, 0x1f, 0x31, 0x3c, 0xb3, 0x21,
+	0x74, 0x3c, 0xcb, 0x8e, 0xdc, 0x78, 0x1f, 0x93, 0xd8, 0x98, 0x64, 0x8c, 0x49, 0xde, 0x80, 0xec,
+	0x16, 0x4f, 0x6d, 0xf3, 0xb7, 0x5d, 0x9a, 0xd7, 0xd2, 0x7c, 0x1e, 0x4a, 0x52, 0x41, 0x4b, 0xc7,
+	0x9d, 0xf8, 0xe3, 0x89, 0xe6, 0x0d, 0x35, 0x5f, 0x7a, 0xa1, 0x5d, 0x83, 0x2e, 0x3c, 0x39, 0x7a,
+	0x89, 0x1f, 0x29, 0xb1, 0x66, 0x8a, 0xaf, 0x94, 0xa8, 0x84, 0xbc, 0x11, 0x6a, 0xc5, 0x38, 0x57,
+	0x42, 0xeb, 0xf8, 0xc4, 0x2d, 0xe3, 0xa1, 0x97, 0x8b, 0x51, 0x7d, 0xed, 0xc5, 0xc5, 0xbb, 0xcd,
+	0x3e, 0x41, 0xdb, 0x7d, 0x82, 0x7e, 0xed, 0x13, 0x74, 0x7b, 0x48, 0x82, 0xed, 0x21, 0x09, 0x7e,
+	0x1c, 0x92, 0xe0, 0xe3, 0x8b, 0xbf, 0x2c, 0x18, 0xc1, 0x5a, 0x5d, 0xb1, 0x6e, 0x0d, 0xaa, 0xb1,
+	0x4e, 0xda, 0x9e, 0x69, 0xed, 0x6d, 0xd0, 0x7f, 0x1f, 0x4d, 0x79, 0xe2, 0x56, 0xf2, 0xfc, 0xf7,
+	0x00, 0x01, 0x1e, 0x87, 0x67, 0x4d, 0x02, 0x00, 0x00,
+}
+
+func (m *PeriodLock) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PeriodLock) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PeriodLock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.RewardReceiverAddress) > 0 {
+		i -= len(m.RewardReceiverAddress)
+		copy(dAtA[i:], m.RewardReceiverAddress)
+		i = encodeVarintLock(dAtA, i, uint64(len(m.RewardReceiverAddress)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.Coins) > 0 {
+		for iNdEx := len(m.Coins) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Coins[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintLock(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	n1, err1 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(m.EndTime, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(m.EndTime):])
+	if err1 != nil {
+		return 0, err1
+	}
+	i -= n1
+	i = encodeVarintLock(dAtA, i, uint64(n1))
+	i--
+	dAtA[i] = 0x22
+	n2, err2 := github_com_cosmos_gogoproto_types.StdDurationMarshalTo(m.Duration, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.Duration):])
+	if err2 != nil {
+		return 0, err2
+	}
+	i -= n2
+	i = encodeVarintLock(dAtA, i, uint64(n2))
+	i--
+	dAtA[i] = 0x1a
+	if len(m.Owner) > 0 {
+		i -= len(m.Owner)
+		copy(dAtA[i:], m.Owner)
+		i = encodeVarintLock(dAtA, i, uint64(len(m.Owner)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.ID != 0 {
+		i = encodeVarintLock(dAtA, i, uint64(m.ID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintLock(dAtA []byte, offset int, v uint64) int {
+	offset -= sovLock(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *PeriodLock) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ID != 0 {
+		n += 1 + sovLock(uint64(m.ID))
+	}
+	l = len(m.Owner)
+	if l > 0 {
+		n += 1 + l + sovLock(uint64(l))
+	}
+	l = github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.Duration)
+	n += 1 + l + sovLock(uint64(l))
+	l = github_com_cosmos_gogoproto_types.SizeOfStdTime(m.EndTime)
+	n += 1 + l + sovLock(uint64(l))
+	if len(m.Coins) > 0 {
+		for _, e := range m.Coins {
+			l = e.Size()
+			n += 1 + l + sovLock(uint64(l))
+		}
+	}
+	l = len(m.RewardReceiverAddress)
+	if l > 0 {
+		n += 1 + l + sovLock(uint64(l))
+	}
+	return n
+}
+
+func sovLock(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozLock(x uint64) (n int) {
+	return sovLock(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *PeriodLock) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLock
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PeriodLock: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PeriodLock: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLock
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Owner", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLock
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLock
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLock
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Owner = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Duration", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLock
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLock
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLock
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_cosmos_gogoproto_types.StdDurationUnmarshal(&m.Duration, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndTime", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLock
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLock
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLock
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_cosmos_gogoproto_types.StdTimeUnmarshal(&m.EndTime, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Coins", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLock
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLock
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLock
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Coins = append(m.Coins, types.Coin{})
+			if err := m.Coins[len(m.Coins)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RewardReceiverAddress", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLock
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLock
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLock
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RewardReceiverAddress = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLock(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLock
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipLock(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowLock
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowLock
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowLock
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthLock
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupLock
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthLock
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthLock        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowLock          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupLock = fmt.Errorf("proto: unexpected end of group")
+)
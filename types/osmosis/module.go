@@ -0,0 +1,68 @@
+// Package osmosis registers the types of the osmosis modules, which can not be imported alongside
+// the version of the cosmos-sdk used by compass. Only the messages, pool models and governance
+// proposals which are commonly used by clients are included
+package osmosis
+
+import (
+	sdkclient "github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/types/module"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/spf13/cobra"
+
+	cl "github.com/teamscanworks/compass/types/osmosis/concentratedliquidity"
+	"github.com/teamscanworks/compass/types/osmosis/cosmwasmpool"
+	"github.com/teamscanworks/compass/types/osmosis/gamm"
+	"github.com/teamscanworks/compass/types/osmosis/lockup"
+	"github.com/teamscanworks/compass/types/osmosis/poolmanager"
+	"github.com/teamscanworks/compass/types/osmosis/stableswap"
+	"github.com/teamscanworks/compass/types/osmosis/superfluid"
+	"github.com/teamscanworks/compass/types/osmosis/tokenfactory"
+	"github.com/teamscanworks/compass/types/osmosis/txfees"
+)
+
+const ModuleName = "osmosis"
+
+var _ module.AppModuleBasic = AppModuleBasic{}
+
+// AppModuleBasic registers the osmosis types for encoding and decoding, it does not
+// provide any client functionality
+type AppModuleBasic struct{}
+
+func (AppModuleBasic) Name() string { return ModuleName }
+
+func (AppModuleBasic) RegisterLegacyAminoCodec(cdc *codec.LegacyAmino) {
+	poolmanager.RegisterLegacyAminoCodec(cdc)
+	gamm.RegisterLegacyAminoCodec(cdc)
+	cl.RegisterLegacyAminoCodec(cdc)
+	tokenfactory.RegisterLegacyAminoCodec(cdc)
+	lockup.RegisterLegacyAminoCodec(cdc)
+	superfluid.RegisterLegacyAminoCodec(cdc)
+	txfees.RegisterLegacyAminoCodec(cdc)
+}
+
+func (AppModuleBasic) RegisterInterfaces(registry types.InterfaceRegistry) {
+	poolmanager.RegisterInterfaces(registry)
+	gamm.RegisterInterfaces(registry)
+	cl.RegisterInterfaces(registry)
+	tokenfactory.RegisterInterfaces(registry)
+	lockup.RegisterInterfaces(registry)
+	superfluid.RegisterInterfaces(registry)
+	txfees.RegisterInterfaces(registry)
+
+	registry.RegisterInterface(
+		"osmosis.poolmanager.v1beta1.PoolI",
+		(*poolmanager.PoolI)(nil),
+		&gamm.Pool{},
+		&stableswap.Pool{},
+		&cl.Pool{},
+		&cosmwasmpool.CosmWasmPool{},
+	)
+}
+
+func (AppModuleBasic) RegisterGRPCGatewayRoutes(sdkclient.Context, *runtime.ServeMux) {}
+
+func (AppModuleBasic) GetTxCmd() *cobra.Command { return nil }
+
+func (AppModuleBasic) GetQueryCmd() *cobra.Command { return nil }
@@ -0,0 +1,41 @@
+// Package poolmanager provides the protobuf types of the osmosis x/poolmanager module, which can not be
+// imported alongside the version of the cosmos-sdk used by compass
+package poolmanager
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/codec/legacy"
+	"github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/msgservice"
+	"github.com/cosmos/gogoproto/proto"
+)
+
+const ModuleName = "poolmanager"
+
+// PoolI is implemented by all pool models, whose implementations are registered by
+// the osmosis module basics
+type PoolI interface {
+	proto.Message
+	GetId() uint64
+}
+
+// Registers the poolmanager messages against the amino codec
+func RegisterLegacyAminoCodec(cdc *codec.LegacyAmino) {
+	// registered directly as the names exceed the length enforced by RegisterAminoMsg
+	cdc.RegisterConcrete(&MsgSwapExactAmountIn{}, "osmosis/poolmanager/swap-exact-amount-in", nil)
+	cdc.RegisterConcrete(&MsgSwapExactAmountOut{}, "osmosis/poolmanager/swap-exact-amount-out", nil)
+	legacy.RegisterAminoMsg(cdc, &MsgSplitRouteSwapExactAmountIn{}, "osmosis/poolmanager/split-amount-in")
+	legacy.RegisterAminoMsg(cdc, &MsgSplitRouteSwapExactAmountOut{}, "osmosis/poolmanager/split-amount-out")
+}
+
+// Registers the poolmanager messages against the interface registry
+func RegisterInterfaces(registry types.InterfaceRegistry) {
+	registry.RegisterImplementations((*sdk.Msg)(nil),
+		&MsgSwapExactAmountIn{},
+		&MsgSwapExactAmountOut{},
+		&MsgSplitRouteSwapExactAmountIn{},
+		&MsgSplitRouteSwapExactAmountOut{},
+	)
+	msgservice.RegisterMsgServiceDesc(registry, &_Msg_serviceDesc)
+}
@@ -0,0 +1,3 @@
+// Package stableswap provides the protobuf types of the osmosis stableswap pool model, which can not be
+// imported alongside the version of the cosmos-sdk used by compass
+package stableswap
@@ -0,0 +1,93 @@
+// Package superfluid provides the protobuf types of the osmosis x/superfluid module, which can not be
+// imported alongside the version of the cosmos-sdk used by compass
+package superfluid
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/codec/legacy"
+	"github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/msgservice"
+	govv1beta1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1beta1"
+)
+
+const (
+	ModuleName = "superfluid"
+
+	ProposalTypeSetSuperfluidAssets    = "SetSuperfluidAssets"
+	ProposalTypeRemoveSuperfluidAssets = "RemoveSuperfluidAssets"
+)
+
+var (
+	_ govv1beta1.Content = &SetSuperfluidAssetsProposal{}
+	_ govv1beta1.Content = &RemoveSuperfluidAssetsProposal{}
+)
+
+// Registers the superfluid messages and proposals against the amino codec
+func RegisterLegacyAminoCodec(cdc *codec.LegacyAmino) {
+	legacy.RegisterAminoMsg(cdc, &MsgSuperfluidDelegate{}, "osmosis/superfluid-delegate")
+	legacy.RegisterAminoMsg(cdc, &MsgSuperfluidUndelegate{}, "osmosis/superfluid-undelegate")
+	legacy.RegisterAminoMsg(cdc, &MsgSuperfluidUnbondLock{}, "osmosis/superfluid-unbond-lock")
+	// registered directly as the name exceeds the length enforced by RegisterAminoMsg
+	cdc.RegisterConcrete(&MsgSuperfluidUndelegateAndUnbondLock{}, "osmosis/superfluid-undelegate-and-unbond-lock", nil)
+	legacy.RegisterAminoMsg(cdc, &MsgLockAndSuperfluidDelegate{}, "osmosis/lock-and-superfluid-delegate")
+	legacy.RegisterAminoMsg(cdc, &MsgUnPoolWhitelistedPool{}, "osmosis/unpool-whitelisted-pool")
+
+	cdc.RegisterConcrete(&SetSuperfluidAssetsProposal{}, "osmosis/set-superfluid-assets-proposal", nil)
+	cdc.RegisterConcrete(&RemoveSuperfluidAssetsProposal{}, "osmosis/del-superfluid-assets-proposal", nil)
+}
+
+// Registers the superfluid messages and proposals against the interface registry
+func RegisterInterfaces(registry types.InterfaceRegistry) {
+	registry.RegisterImplementations((*sdk.Msg)(nil),
+		&MsgSuperfluidDelegate{},
+		&MsgSuperfluidUndelegate{},
+		&MsgSuperfluidUnbondLock{},
+		&MsgSuperfluidUndelegateAndUnbondLock{},
+		&MsgLockAndSuperfluidDelegate{},
+		&MsgUnPoolWhitelistedPool{},
+	)
+	registry.RegisterImplementations((*govv1beta1.Content)(nil),
+		&SetSuperfluidAssetsProposal{},
+		&RemoveSuperfluidAssetsProposal{},
+	)
+	msgservice.RegisterMsgServiceDesc(registry, &_Msg_serviceDesc)
+}
+
+func (p *SetSuperfluidAssetsProposal) GetTitle() string { return p.Title }
+
+func (p *SetSuperfluidAssetsProposal) GetDescription() string { return p.Description }
+
+func (p *SetSuperfluidAssetsProposal) ProposalRoute() string { return ModuleName }
+
+func (p *SetSuperfluidAssetsProposal) ProposalType() string {
+	return ProposalTypeSetSuperfluidAssets
+}
+
+func (p *SetSuperfluidAssetsProposal) ValidateBasic() error {
+	return govv1beta1.ValidateAbstract(p)
+}
+
+func (p SetSuperfluidAssetsProposal) String() string {
+	return fmt.Sprintf("Set Superfluid Assets Proposal:\n  Title:       %s\n  Description: %s\n  Assets:      %v\n", p.Title, p.Description, p.Assets)
+}
+
+func (p *RemoveSuperfluidAssetsProposal) GetTitle() string { return p.Title }
+
+func (p *RemoveSuperfluidAssetsProposal) GetDescription() string { return p.Description }
+
+func (p *RemoveSuperfluidAssetsProposal) ProposalRoute() string { return ModuleName }
+
+func (p *RemoveSuperfluidAssetsProposal) ProposalType() string {
+	return ProposalTypeRemoveSuperfluidAssets
+}
+
+func (p *RemoveSuperfluidAssetsProposal) ValidateBasic() error {
+	return govv1beta1.ValidateAbstract(p)
+}
+
+func (p RemoveSuperfluidAssetsProposal) String() string {
+	return fmt.Sprintf("Remove Superfluid Assets Proposal:\n  Title:       %s\n  Description: %s\n  Denoms:      %v\n", p.Title, p.Description, p.SuperfluidAssetDenoms)
+}
@@ -0,0 +1,421 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: osmosis/superfluid/superfluid.proto
+
+package superfluid
+
+import (
+	fmt "fmt"
+	_ "github.com/cosmos/gogoproto/gogoproto"
+	proto "github.com/cosmos/gogoproto/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// SuperfluidAssetType indicates whether the superfluid asset is
+// a native token, lp share of a pool, or concentrated share of a pool
+type SuperfluidAssetType int32
+
+const (
+	SuperfluidAssetTypeNative            SuperfluidAssetType = 0
+	SuperfluidAssetTypeLPShare           SuperfluidAssetType = 1
+	SuperfluidAssetTypeConcentratedShare SuperfluidAssetType = 2
+)
+
+var SuperfluidAssetType_name = map[int32]string{
+	0: "SuperfluidAssetTypeNative",
+	1: "SuperfluidAssetTypeLPShare",
+	2: "SuperfluidAssetTypeConcentratedShare",
+}
+
+var SuperfluidAssetType_value = map[string]int32{
+	"SuperfluidAssetTypeNative":            0,
+	"SuperfluidAssetTypeLPShare":           1,
+	"SuperfluidAssetTypeConcentratedShare": 2,
+}
+
+func (x SuperfluidAssetType) String() string {
+	return proto.EnumName(SuperfluidAssetType_name, int32(x))
+}
+
+func (SuperfluidAssetType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_79d3c29d82dbb734, []int{0}
+}
+
+// SuperfluidAsset stores the pair of superfluid asset type and denom pair
+type SuperfluidAsset struct {
+	Denom string `protobuf:"bytes,1,opt,name=denom,proto3" json:"denom,omitempty"`
+	// AssetType indicates whether the superfluid asset is a native token or an lp
+	// share
+	AssetType SuperfluidAssetType `protobuf:"varint,2,opt,name=asset_type,json=assetType,proto3,enum=osmosis.superfluid.SuperfluidAssetType" json:"asset_type,omitempty"`
+}
+
+func (m *SuperfluidAsset) Reset()         { *m = SuperfluidAsset{} }
+func (m *SuperfluidAsset) String() string { return proto.CompactTextString(m) }
+func (*SuperfluidAsset) ProtoMessage()    {}
+func (*SuperfluidAsset) Descriptor() ([]byte, []int) {
+	return fileDescriptor_79d3c29d82dbb734, []int{0}
+}
+func (m *SuperfluidAsset) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SuperfluidAsset) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SuperfluidAsset.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SuperfluidAsset) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SuperfluidAsset.Merge(m, src)
+}
+func (m *SuperfluidAsset) XXX_Size() int {
+	return m.Size()
+}
+func (m *SuperfluidAsset) XXX_DiscardUnknown() {
+	xxx_messageInfo_SuperfluidAsset.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SuperfluidAsset proto.InternalMessageInfo
+
+func (m *SuperfluidAsset) GetDenom() string {
+	if m != nil {
+		return m.Denom
+	}
+	return ""
+}
+
+func (m *SuperfluidAsset) GetAssetType() SuperfluidAssetType {
+	if m != nil {
+		return m.AssetType
+	}
+	return SuperfluidAssetTypeNative
+}
+
+func init() {
+	proto.RegisterEnum("osmosis.superfluid.SuperfluidAssetType", SuperfluidAssetType_name, SuperfluidAssetType_value)
+	proto.RegisterType((*SuperfluidAsset)(nil), "osmosis.superfluid.SuperfluidAsset")
+}
+
+func init() {
+	proto.RegisterFile("osmosis/superfluid/superfluid.proto", fileDescriptor_79d3c29d82dbb734)
+}
+
+var fileDescriptor_79d3c29d82dbb734 = []byte{
+	// 278 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x52, 0xce, 0x2f, 0xce, 0xcd,
+	0x2f, 0xce, 0x2c, 0xd6, 0x2f, 0x2e, 0x2d, 0x48, 0x2d, 0x4a, 0xcb, 0x29, 0xcd, 0x4c, 0x41, 0x62,
+	0xea, 0x15, 0x14, 0xe5, 0x97, 0xe4, 0x0b, 0x09, 0x41, 0x15, 0xe9, 0x21, 0x64, 0xa4, 0x44, 0xd2,
+	0xf3, 0xd3, 0xf3, 0xc1, 0xd2, 0xfa, 0x20, 0x16, 0x44, 0xa5, 0x52, 0x29, 0x17, 0x7f, 0x30, 0x5c,
+	0x8d, 0x63, 0x71, 0x71, 0x6a, 0x89, 0x90, 0x08, 0x17, 0x6b, 0x4a, 0x6a, 0x5e, 0x7e, 0xae, 0x04,
+	0xa3, 0x02, 0xa3, 0x06, 0x67, 0x10, 0x84, 0x23, 0xe4, 0xc6, 0xc5, 0x95, 0x08, 0x92, 0x8e, 0x2f,
+	0xa9, 0x2c, 0x48, 0x95, 0x60, 0x52, 0x60, 0xd4, 0xe0, 0x33, 0x52, 0xd7, 0xc3, 0xb4, 0x47, 0x0f,
+	0xcd, 0xb8, 0x90, 0xca, 0x82, 0xd4, 0x20, 0xce, 0x44, 0x18, 0xd3, 0x8a, 0xe5, 0xc5, 0x02, 0x79,
+	0x46, 0xad, 0x16, 0x46, 0x2e, 0x61, 0x2c, 0x0a, 0x85, 0x64, 0xb9, 0x24, 0xb1, 0x08, 0xfb, 0x25,
+	0x96, 0x64, 0x96, 0xa5, 0x0a, 0x30, 0x08, 0xc9, 0x71, 0x49, 0x61, 0x91, 0xf6, 0x09, 0x08, 0xce,
+	0x48, 0x2c, 0x4a, 0x15, 0x60, 0x14, 0xd2, 0xe0, 0x52, 0xc1, 0x22, 0xef, 0x9c, 0x9f, 0x97, 0x9c,
+	0x9a, 0x57, 0x52, 0x94, 0x58, 0x92, 0x9a, 0x02, 0x51, 0xc9, 0x24, 0xc5, 0xd2, 0xb1, 0x58, 0x8e,
+	0xc1, 0x29, 0xf8, 0xc4, 0x23, 0x39, 0xc6, 0x0b, 0x8f, 0xe4, 0x18, 0x1f, 0x3c, 0x92, 0x63, 0x9c,
+	0xf0, 0x58, 0x8e, 0xe1, 0xc2, 0x63, 0x39, 0x86, 0x1b, 0x8f, 0xe5, 0x18, 0xa2, 0x2c, 0xd3, 0x33,
+	0x4b, 0x32, 0x4a, 0x93, 0xf4, 0x92, 0xf3, 0x73, 0xf5, 0x4b, 0x52, 0x13, 0x73, 0x8b, 0x93, 0x13,
+	0xf3, 0xca, 0xf3, 0x8b, 0xb2, 0x8b, 0xf5, 0x93, 0xf3, 0x73, 0x0b, 0x12, 0x8b, 0x8b, 0xf5, 0x41,
+	0xe1, 0x50, 0xac, 0x8f, 0x19, 0x1b, 0x49, 0x6c, 0xe0, 0x90, 0x35, 0x06, 0x0c, 0x00, 0xb4, 0x8c,
+	0x60, 0x43, 0xaa, 0x01, 0x00, 0x00,
+}
+
+func (this *SuperfluidAsset) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*SuperfluidAsset)
+	if !ok {
+		that2, ok := that.(SuperfluidAsset)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Denom != that1.Denom {
+		return false
+	}
+	if this.AssetType != that1.AssetType {
+		return false
+	}
+	return true
+}
+func (m *SuperfluidAsset) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SuperfluidAsset) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SuperfluidAsset) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.AssetType != 0 {
+		i = encodeVarintSuperfluid(dAtA, i, uint64(m.AssetType))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Denom) > 0 {
+		i -= len(m.Denom)
+		copy(dAtA[i:], m.Denom)
+		i = encodeVarintSuperfluid(dAtA, i, uint64(len(m.Denom)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintSuperfluid(dAtA []byte, offset int, v uint64) int {
+	offset -= sovSuperfluid(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *SuperfluidAsset) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Denom)
+	if l > 0 {
+		n += 1 + l + sovSuperfluid(uint64(l))
+	}
+	if m.AssetType != 0 {
+		n += 1 + sovSuperfluid(uint64(m.AssetType))
+	}
+	return n
+}
+
+func sovSuperfluid(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozSuperfluid(x uint64) (n int) {
+	return sovSuperfluid(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *SuperfluidAsset) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSuperfluid
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SuperfluidAsset: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SuperfluidAsset: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Denom", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSuperfluid
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSuperfluid
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSuperfluid
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Denom = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AssetType", wireType)
+			}
+			m.AssetType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSuperfluid
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AssetType |= SuperfluidAssetType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSuperfluid(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSuperfluid
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipSuperfluid(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowSuperfluid
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowSuperfluid
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowSuperfluid
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthSuperfluid
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupSuperfluid
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthSuperfluid
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthSuperfluid        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowSuperfluid          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupSuperfluid = fmt.Errorf("proto: unexpected end of group")
+)
@@ -0,0 +1,45 @@
+// Package txfees provides the protobuf types of the osmosis x/txfees module, which can not be
+// imported alongside the version of the cosmos-sdk used by compass
+package txfees
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/codec/types"
+	govv1beta1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1beta1"
+)
+
+const (
+	ModuleName = "txfees"
+
+	ProposalTypeUpdateFeeToken = "UpdateFeeToken"
+)
+
+var _ govv1beta1.Content = &UpdateFeeTokenProposal{}
+
+// Registers the txfees proposals against the amino codec
+func RegisterLegacyAminoCodec(cdc *codec.LegacyAmino) {
+	cdc.RegisterConcrete(&UpdateFeeTokenProposal{}, "osmosis/UpdateFeeTokenProposal", nil)
+}
+
+// Registers the txfees proposals against the interface registry
+func RegisterInterfaces(registry types.InterfaceRegistry) {
+	registry.RegisterImplementations((*govv1beta1.Content)(nil), &UpdateFeeTokenProposal{})
+}
+
+func (p *UpdateFeeTokenProposal) GetTitle() string { return p.Title }
+
+func (p *UpdateFeeTokenProposal) GetDescription() string { return p.Description }
+
+func (p *UpdateFeeTokenProposal) ProposalRoute() string { return ModuleName }
+
+func (p *UpdateFeeTokenProposal) ProposalType() string { return ProposalTypeUpdateFeeToken }
+
+func (p *UpdateFeeTokenProposal) ValidateBasic() error {
+	return govv1beta1.ValidateAbstract(p)
+}
+
+func (p UpdateFeeTokenProposal) String() string {
+	return fmt.Sprintf("Update Fee Token Proposal:\n  Title:       %s\n  Description: %s\n  Records:     %v\n", p.Title, p.Description, p.Feetokens)
+}
@@ -0,0 +1,81 @@
+// Package wasm provides the protobuf types of the wasmd x/wasm module, which can not be imported
+// alongside the version of the cosmos-sdk used by compass.
+//
+// Contract messages are encoded as base64 when signing with the amino json sign mode, while wasmd
+// inlines them as json, so transactions containing wasm messages must be signed in direct mode
+package wasm
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/codec/legacy"
+	"github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/address"
+	"github.com/cosmos/cosmos-sdk/types/msgservice"
+)
+
+const (
+	ModuleName = "wasm"
+	// length of contract addresses
+	ContractAddrLen = 32
+	// maximum length of the salt used to derive predictable contract addresses
+	MaxSaltSize = 64
+)
+
+// Registers the wasm messages against the amino codec
+func RegisterLegacyAminoCodec(cdc *codec.LegacyAmino) {
+	legacy.RegisterAminoMsg(cdc, &MsgStoreCode{}, "wasm/MsgStoreCode")
+	legacy.RegisterAminoMsg(cdc, &MsgInstantiateContract{}, "wasm/MsgInstantiateContract")
+	legacy.RegisterAminoMsg(cdc, &MsgInstantiateContract2{}, "wasm/MsgInstantiateContract2")
+	legacy.RegisterAminoMsg(cdc, &MsgExecuteContract{}, "wasm/MsgExecuteContract")
+	legacy.RegisterAminoMsg(cdc, &MsgMigrateContract{}, "wasm/MsgMigrateContract")
+	legacy.RegisterAminoMsg(cdc, &MsgUpdateAdmin{}, "wasm/MsgUpdateAdmin")
+	legacy.RegisterAminoMsg(cdc, &MsgClearAdmin{}, "wasm/MsgClearAdmin")
+}
+
+// Registers the wasm messages against the interface registry
+func RegisterInterfaces(registry types.InterfaceRegistry) {
+	registry.RegisterImplementations((*sdk.Msg)(nil),
+		&MsgStoreCode{},
+		&MsgInstantiateContract{},
+		&MsgInstantiateContract2{},
+		&MsgExecuteContract{},
+		&MsgMigrateContract{},
+		&MsgUpdateAdmin{},
+		&MsgClearAdmin{},
+	)
+	msgservice.RegisterMsgServiceDesc(registry, &_Msg_serviceDesc)
+}
+
+func (a AccessType) String() string {
+	switch a {
+	case AccessTypeNobody:
+		return "Nobody"
+	case AccessTypeEverybody:
+		return "Everybody"
+	case AccessTypeAnyOfAddresses:
+		return "AnyOfAddresses"
+	}
+	return "Unspecified"
+}
+
+// Returns the address of a contract instantiated with `MsgInstantiateContract2`, given the checksum of
+// its code, the creator and the salt. The instantiation message is only included when `fix_msg` is set,
+// and should be nil otherwise
+func BuildContractAddressPredictable(checksum []byte, creator sdk.AccAddress, salt, initMsg []byte) sdk.AccAddress {
+	var key []byte
+	for _, part := range [][]byte{checksum, creator, salt, initMsg} {
+		key = binary.BigEndian.AppendUint64(key, uint64(len(part)))
+		key = append(key, part...)
+	}
+	return address.Module(ModuleName, key)[:ContractAddrLen]
+}
+
+// Returns the checksum of the given wasm code, as used to derive predictable contract addresses
+func Checksum(wasmCode []byte) []byte {
+	sum := sha256.Sum256(wasmCode)
+	return sum[:]
+}
@@ -0,0 +1,45 @@
+package wasm
+
+import (
+	context "context"
+
+	grpc1 "github.com/cosmos/gogoproto/grpc"
+	proto "github.com/cosmos/gogoproto/proto"
+	grpc "google.golang.org/grpc"
+)
+
+// The CodeInfo query was added in wasmd v0.51, after the protobuf types of this package were generated.
+// Unlike the Code query it returns the metadata of the code without its bytecode
+
+// Full method name of the CodeInfo query
+const QueryCodeInfoMethod = "/cosmwasm.wasm.v1.Query/CodeInfo"
+
+// QueryCodeInfoRequest is the request type for the Query/CodeInfo RPC method
+type QueryCodeInfoRequest struct {
+	CodeId uint64 `protobuf:"varint,1,opt,name=code_id,json=codeId,proto3" json:"code_id,omitempty"`
+}
+
+func (m *QueryCodeInfoRequest) Reset()         { *m = QueryCodeInfoRequest{} }
+func (m *QueryCodeInfoRequest) String() string { return proto.CompactTextString(m) }
+func (*QueryCodeInfoRequest) ProtoMessage()    {}
+
+// QueryCodeInfoResponse is the response type for the Query/CodeInfo RPC method
+type QueryCodeInfoResponse struct {
+	CodeID                uint64        `protobuf:"varint,1,opt,name=code_id,json=codeId,proto3" json:"code_id,omitempty"`
+	Creator               string        `protobuf:"bytes,2,opt,name=creator,proto3" json:"creator,omitempty"`
+	Checksum              []byte        `protobuf:"bytes,3,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	InstantiatePermission *AccessConfig `protobuf:"bytes,4,opt,name=instantiate_permission,json=instantiatePermission,proto3" json:"instantiate_permission,omitempty"`
+}
+
+func (m *QueryCodeInfoResponse) Reset()         { *m = QueryCodeInfoResponse{} }
+func (m *QueryCodeInfoResponse) String() string { return proto.CompactTextString(m) }
+func (*QueryCodeInfoResponse) ProtoMessage()    {}
+
+// Queries the metadata of the code through the CodeInfo query
+func QueryCodeInfo(ctx context.Context, cc grpc1.ClientConn, in *QueryCodeInfoRequest, opts ...grpc.CallOption) (*QueryCodeInfoResponse, error) {
+	out := new(QueryCodeInfoResponse)
+	if err := cc.Invoke(ctx, QueryCodeInfoMethod, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -4,29 +4,39 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
+	"net/http"
 	"time"
 
 	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
 	libclient "github.com/cometbft/cometbft/rpc/jsonrpc/client"
+	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/tx"
 	"github.com/cosmos/cosmos-sdk/crypto/hd"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/go-bip39"
+	"github.com/cosmos/gogoproto/proto"
 )
 
 // Returns a Cosmos JSON-RPC websocket client
 func NewRPCClient(addr string, timeout time.Duration) (*rpchttp.HTTP, error) {
+	rpcClient, _, err := newRPCClient(addr, timeout)
+	return rpcClient, err
+}
+
+// returns an rpc client, and the http client it sends requests through
+func newRPCClient(addr string, timeout time.Duration) (*rpchttp.HTTP, *http.Client, error) {
 	httpClient, err := libclient.DefaultHTTPClient(addr)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	httpClient.Timeout = timeout
 	rpcClient, err := rpchttp.NewWithClient(addr, "/websocket", httpClient)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return rpcClient, nil
+	return rpcClient, httpClient, nil
 }
 
 // returns a keyring.Option that specifies a list of default algorithms
@@ -56,33 +66,121 @@ func CreateMnemonic() (string, error) {
 //
 // To be as safe as possible it's recommended the caller use `SendTransaction`
 func (c *Client) BroadcastTx(ctx context.Context, msgs ...sdk.Msg) (string, error) {
+	if err := c.checkClosed(); err != nil {
+		return "", err
+	}
+	// held so that `Close` waits for the transaction to be broadcast
+	c.txLock.Lock()
+	defer c.txLock.Unlock()
+	return c.broadcastTx(ctx, "", nil, msgs...)
+}
+
+// signs and broadcasts the messages, remembering the hash of the transaction under the idempotency key if one is given.
+// Fees are paid at the given gas price, or at the price selected by `SelectGasPrice` if none is given
+func (c *Client) broadcastTx(ctx context.Context, idempotencyKey string, gasPrice *sdk.DecCoin, msgs ...sdk.Msg) (txHash string, err error) {
+	ctx, span := c.startSpan(ctx, "compass.BroadcastTx",
+		AttributeSigner.String(c.FromAddress()),
+		AttributeMsgTypes.StringSlice(msgTypeURLs(msgs)),
+	)
+	defer func() { endSpan(span, err) }()
+
+	factory, unsignedTx, err := c.buildTx(ctx, gasPrice, msgs)
+	if err != nil {
+		return "", err
+	}
+	txBytes, err := c.signTx(ctx, factory, unsignedTx)
+	if err != nil {
+		return "", err
+	}
+	hash, err := c.journalSigned(txBytes, factory.Sequence(), msgTypeURLs(msgs), idempotencyKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to journal transaction %s", err)
+	}
+	c.rememberIdempotentTx(idempotencyKey, hash)
+	res, err := c.submitTx(ctx, hash, txBytes)
+	if err != nil {
+		return "", err
+	}
+	span.SetAttributes(AttributeTxHash.String(res.TxHash))
+	if err := c.confirmTx(ctx, res.TxHash, unsignedTx.GetTx().GetFee()); err != nil {
+		return "", err
+	}
+	return res.TxHash, nil
+}
+
+// builds the unsigned transaction paying fees at the given gas price if any, and the factory used to sign it
+func (c *Client) buildTx(ctx context.Context, gasPrice *sdk.DecCoin, msgs []sdk.Msg) (_ tx.Factory, _ client.TxBuilder, err error) {
+	_, span := c.startSpan(ctx, "compass.build")
+	defer func() { endSpan(span, err) }()
+
 	factory, err := c.factory.Prepare(c.cctx)
 	if err != nil {
-		return "", fmt.Errorf("failed to prepare transaction %s", err)
+		return tx.Factory{}, nil, fmt.Errorf("failed to prepare transaction %s", err)
 	}
+	if gasPrice == nil {
+		// fail before signing rather than having the transaction rejected for insufficient fees
+		selected, err := c.SelectGasPrice(ctx, factory.Gas())
+		if err != nil {
+			return tx.Factory{}, nil, err
+		}
+		gasPrice = &selected
+	}
+	factory = factory.WithGasPrices(gasPriceString(*gasPrice))
 
 	unsignedTx, err := factory.BuildUnsignedTx(msgs...)
 	if err != nil {
-		return "", fmt.Errorf("failed to build unsigned transaction %s", err)
+		return tx.Factory{}, nil, fmt.Errorf("failed to build unsigned transaction %s", err)
 	}
+	span.SetAttributes(AttributeGasWanted.Int64(int64(unsignedTx.GetTx().GetGas())))
+	return factory, unsignedTx, nil
+}
+
+// signs the transaction, returning its encoding
+func (c *Client) signTx(ctx context.Context, factory tx.Factory, unsignedTx client.TxBuilder) (_ []byte, err error) {
+	_, span := c.startSpan(ctx, "compass.sign", AttributeSigner.String(c.FromAddress()))
+	defer func() { endSpan(span, err) }()
 
 	if err := tx.Sign(c.cctx.CmdContext, factory, c.cctx.GetFromName(), unsignedTx, true); err != nil {
-		return "", fmt.Errorf("failed to sign transaction %s", err)
+		return nil, fmt.Errorf("failed to sign transaction %s", err)
 	}
 
 	txBytes, err := c.cctx.TxConfig.TxEncoder()(unsignedTx.GetTx())
 	if err != nil {
-		return "", fmt.Errorf("failed to get transaction encoder %s", err)
+		return nil, fmt.Errorf("failed to get transaction encoder %s", err)
 	}
+	return txBytes, nil
+}
 
-	res, err := c.cctx.BroadcastTx(txBytes)
+// broadcasts the transaction, failing if it is rejected by the node
+func (c *Client) submitTx(ctx context.Context, hash string, txBytes []byte) (_ *sdk.TxResponse, err error) {
+	_, span := c.startSpan(ctx, "compass.broadcast", AttributeTxHash.String(hash))
+	defer func() { endSpan(span, err) }()
+
+	res, err := c.cctx.WithClient(c.rpcClient()).BroadcastTx(txBytes)
 	if err != nil {
-		return "", fmt.Errorf("failed to broadcast transaction %s", err)
+		// the node may have accepted the transaction, so it remains pending in the journal
+		c.cfg.Metrics.observeTx(TxOutcomeError, "", 0)
+		return nil, fmt.Errorf("failed to broadcast transaction %s", err)
+	}
+	span.SetAttributes(AttributeTxCode.Int64(int64(res.Code)))
+	if res.Code != 0 {
+		c.cfg.Metrics.observeTx(TxOutcomeRejected, res.Codespace, res.Code)
+		c.journalRejected(hash, res.RawLog)
+		return nil, fmt.Errorf("transaction rejected with code %d (%s): %s", res.Code, res.Codespace, res.RawLog)
 	}
+	c.journalBroadcast(hash)
+	return res, nil
+}
+
+// waits for the transaction to be included in a block
+func (c *Client) confirmTx(ctx context.Context, txHash string, fee sdk.Coins) (err error) {
+	ctx, span := c.startSpan(ctx, "compass.confirm", AttributeTxHash.String(txHash))
+	defer func() { endSpan(span, err) }()
+	broadcastAt := time.Now()
 
-	txBytes, err = hex.DecodeString(res.TxHash)
+	hash, err := hex.DecodeString(txHash)
 	if err != nil {
-		return "", fmt.Errorf("failed to decode string %s", err)
+		return fmt.Errorf("failed to decode string %s", err)
 	}
 
 	// allow up to 10 seconds for the transaction to be confirmed before bailing
@@ -94,12 +192,28 @@ func (c *Client) BroadcastTx(ctx context.Context, msgs ...sdk.Msg) (string, erro
 	for {
 		select {
 		case <-exitTicker:
-			return "", fmt.Errorf("failed to confirm transaction")
+			c.cfg.Metrics.observeTx(TxOutcomeError, "", 0)
+			return fmt.Errorf("failed to confirm transaction")
 		case <-checkTicker.C:
-			if _, err := c.cctx.Client.Tx(ctx, txBytes, false); err != nil {
+			included, err := c.rpcClient().Tx(ctx, hash, false)
+			if err != nil {
 				continue
 			}
-			return res.TxHash, nil
+			result := included.TxResult
+			span.SetAttributes(
+				AttributeHeight.Int64(included.Height),
+				AttributeTxCode.Int64(int64(result.Code)),
+				AttributeGasWanted.Int64(result.GasWanted),
+				AttributeGasUsed.Int64(result.GasUsed),
+			)
+			outcome := TxOutcomeCommitted
+			if result.Code != 0 {
+				outcome = TxOutcomeFailed
+			}
+			c.cfg.Metrics.observeTx(outcome, result.Codespace, result.Code)
+			c.cfg.Metrics.observeInclusion(time.Since(broadcastAt), result.GasUsed, result.GasWanted, fee)
+			c.journalIncluded(txHash, included.Height, result.Code, result.Log)
+			return nil
 		}
 	}
 }
@@ -113,3 +227,49 @@ func (c *Client) FromAddress() string {
 func (c *Client) FromName() string {
 	return c.cctx.GetFromName()
 }
+
+// Returns the result of the transaction with the given hash, failing if the transaction was not
+// successfully executed
+func (c *Client) txResult(ctx context.Context, txHash string) (*coretypes.ResultTx, error) {
+	hash, err := hex.DecodeString(txHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode transaction hash %s", err)
+	}
+	res, err := c.rpcClient().Tx(ctx, hash, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query transaction %s %v", txHash, err)
+	}
+	if res.TxResult.Code != 0 {
+		return res, fmt.Errorf("transaction %s failed with code %v: %s", txHash, res.TxResult.Code, res.TxResult.Log)
+	}
+	return res, nil
+}
+
+// Decodes the response of the message at the given index of a successfully executed transaction
+func (c *Client) txMsgResponse(ctx context.Context, txHash string, idx int, resp proto.Message) error {
+	res, err := c.txResult(ctx, txHash)
+	if err != nil {
+		return err
+	}
+	var msgData sdk.TxMsgData
+	if err := proto.Unmarshal(res.TxResult.Data, &msgData); err != nil {
+		return fmt.Errorf("failed to decode transaction data %s", err)
+	}
+	if idx >= len(msgData.MsgResponses) {
+		return fmt.Errorf("transaction %s has no response for message %d", txHash, idx)
+	}
+	msgResp := msgData.MsgResponses[idx]
+	if typeURL := "/" + proto.MessageName(resp); msgResp.TypeUrl != typeURL {
+		return fmt.Errorf("unexpected message response %s, expected %s", msgResp.TypeUrl, typeURL)
+	}
+	return proto.Unmarshal(msgResp.Value, resp)
+}
+
+// Sends the message, decoding its response once the transaction has been successfully executed
+func (c *Client) sendMsg(ctx context.Context, msg sdk.Msg, resp proto.Message) error {
+	txHash, err := c.SendTransaction(ctx, msg)
+	if err != nil {
+		return err
+	}
+	return c.txMsgResponse(ctx, txHash, 0, resp)
+}
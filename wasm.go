@@ -0,0 +1,269 @@
+package compass
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/teamscanworks/compass/types/wasm"
+)
+
+// magic number prefixing gzip compressed data
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// Uploads the wasm code, returning the assigned code id and checksum. Uncompressed code is gzip compressed
+// before being uploaded. The instantiate permission is optional, defaulting to the chain's parameters
+func (c *Client) StoreCode(ctx context.Context, wasmCode []byte, instantiatePermission *wasm.AccessConfig) (*wasm.MsgStoreCodeResponse, error) {
+	if !bytes.HasPrefix(wasmCode, gzipMagic) {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		if _, err := zw.Write(wasmCode); err != nil {
+			return nil, fmt.Errorf("failed to compress wasm code %s", err)
+		}
+		if err := zw.Close(); err != nil {
+			return nil, fmt.Errorf("failed to compress wasm code %s", err)
+		}
+		wasmCode = buf.Bytes()
+	}
+	msg := &wasm.MsgStoreCode{
+		Sender:                c.FromAddress(),
+		WASMByteCode:          wasmCode,
+		InstantiatePermission: instantiatePermission,
+	}
+	resp := &wasm.MsgStoreCodeResponse{}
+	return resp, c.sendMsg(ctx, msg, resp)
+}
+
+// Instantiates a contract from the given code, returning its address. The instantiation message is encoded
+// as json, and `admin` may be left empty for contracts which can not be migrated
+func (c *Client) InstantiateContract(
+	ctx context.Context,
+	codeID uint64,
+	label string,
+	initMsg any,
+	funds sdk.Coins,
+	admin string,
+) (*wasm.MsgInstantiateContractResponse, error) {
+	rawMsg, err := contractMsg(initMsg)
+	if err != nil {
+		return nil, err
+	}
+	msg := &wasm.MsgInstantiateContract{
+		Sender: c.FromAddress(),
+		Admin:  admin,
+		CodeID: codeID,
+		Label:  label,
+		Msg:    rawMsg,
+		Funds:  funds,
+	}
+	resp := &wasm.MsgInstantiateContractResponse{}
+	return resp, c.sendMsg(ctx, msg, resp)
+}
+
+// Instantiates a contract from the given code at a predictable address derived from the code checksum,
+// the sender and the salt, and when `fixMsg` is set the instantiation message. The address can be computed
+// ahead of time with `PredictContractAddress`
+func (c *Client) InstantiateContract2(
+	ctx context.Context,
+	codeID uint64,
+	label string,
+	initMsg any,
+	funds sdk.Coins,
+	admin string,
+	salt []byte,
+	fixMsg bool,
+) (*wasm.MsgInstantiateContract2Response, error) {
+	if len(salt) == 0 || len(salt) > wasm.MaxSaltSize {
+		return nil, fmt.Errorf("salt must be between 1 and %d bytes", wasm.MaxSaltSize)
+	}
+	rawMsg, err := contractMsg(initMsg)
+	if err != nil {
+		return nil, err
+	}
+	msg := &wasm.MsgInstantiateContract2{
+		Sender: c.FromAddress(),
+		Admin:  admin,
+		CodeID: codeID,
+		Label:  label,
+		Msg:    rawMsg,
+		Funds:  funds,
+		Salt:   salt,
+		FixMsg: fixMsg,
+	}
+	resp := &wasm.MsgInstantiateContract2Response{}
+	return resp, c.sendMsg(ctx, msg, resp)
+}
+
+// Returns the address of the contract which is instantiated by `InstantiateContract2` with the given
+// creator, salt and instantiation message
+func (c *Client) PredictContractAddress(ctx context.Context, codeID uint64, creator string, salt []byte, initMsg any, fixMsg bool) (string, error) {
+	creatorAddr, err := c.DecodeBech32AccAddr(creator)
+	if err != nil {
+		return "", fmt.Errorf("invalid creator address %s", err)
+	}
+	codeInfo, err := c.CodeInfo(ctx, codeID)
+	if err != nil {
+		return "", err
+	}
+	var rawMsg []byte
+	if fixMsg {
+		if rawMsg, err = contractMsg(initMsg); err != nil {
+			return "", err
+		}
+	}
+	return c.EncodeBech32AccAddr(wasm.BuildContractAddressPredictable(codeInfo.DataHash, creatorAddr, salt, rawMsg))
+}
+
+// Executes the contract with the json encoded message, transferring the funds to the contract
+func (c *Client) ExecuteContract(ctx context.Context, contract string, execMsg any, funds sdk.Coins) (*wasm.MsgExecuteContractResponse, error) {
+	rawMsg, err := contractMsg(execMsg)
+	if err != nil {
+		return nil, err
+	}
+	msg := &wasm.MsgExecuteContract{
+		Sender:   c.FromAddress(),
+		Contract: contract,
+		Msg:      rawMsg,
+		Funds:    funds,
+	}
+	resp := &wasm.MsgExecuteContractResponse{}
+	return resp, c.sendMsg(ctx, msg, resp)
+}
+
+// Migrates the contract to the given code with the json encoded migration message. The sender
+// must be the admin of the contract
+func (c *Client) MigrateContract(ctx context.Context, contract string, codeID uint64, migrateMsg any) (*wasm.MsgMigrateContractResponse, error) {
+	rawMsg, err := contractMsg(migrateMsg)
+	if err != nil {
+		return nil, err
+	}
+	msg := &wasm.MsgMigrateContract{
+		Sender:   c.FromAddress(),
+		Contract: contract,
+		CodeID:   codeID,
+		Msg:      rawMsg,
+	}
+	resp := &wasm.MsgMigrateContractResponse{}
+	return resp, c.sendMsg(ctx, msg, resp)
+}
+
+// Runs a smart query against the contract, encoding the query as json and decoding the json
+// response into `result`
+func (c *Client) QueryContractSmart(ctx context.Context, contract string, queryMsg any, result any) error {
+	rawQuery, err := contractMsg(queryMsg)
+	if err != nil {
+		return err
+	}
+	res, err := wasm.NewQueryClient(c.grpcConn()).SmartContractState(ctx, &wasm.QuerySmartContractStateRequest{
+		Address:   contract,
+		QueryData: rawQuery,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to query contract %s", err)
+	}
+	if err := json.Unmarshal(res.Data, result); err != nil {
+		return fmt.Errorf("failed to decode contract response %s", err)
+	}
+	return nil
+}
+
+// Returns the value stored under the key in the raw contract storage, which is nil if the key is not set
+func (c *Client) QueryContractRaw(ctx context.Context, contract string, key []byte) ([]byte, error) {
+	res, err := wasm.NewQueryClient(c.grpcConn()).RawContractState(ctx, &wasm.QueryRawContractStateRequest{
+		Address:   contract,
+		QueryData: key,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query contract state %s", err)
+	}
+	return res.Data, nil
+}
+
+// Returns the metadata of the contract
+func (c *Client) ContractInfo(ctx context.Context, contract string) (*wasm.ContractInfo, error) {
+	res, err := wasm.NewQueryClient(c.grpcConn()).ContractInfo(ctx, &wasm.QueryContractInfoRequest{Address: contract})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query contract info %s", err)
+	}
+	return &res.ContractInfo, nil
+}
+
+// Returns the code history of the contract, starting with its instantiation
+func (c *Client) ContractHistory(ctx context.Context, contract string) ([]wasm.ContractCodeHistoryEntry, error) {
+	queryClient := wasm.NewQueryClient(c.grpcConn())
+	var (
+		entries []wasm.ContractCodeHistoryEntry
+		nextKey []byte
+	)
+	for {
+		res, err := queryClient.ContractHistory(ctx, &wasm.QueryContractHistoryRequest{
+			Address:    contract,
+			Pagination: &query.PageRequest{Key: nextKey},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to query contract history %s", err)
+		}
+		entries = append(entries, res.Entries...)
+		if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+			return entries, nil
+		}
+		nextKey = res.Pagination.NextKey
+	}
+}
+
+// Returns the metadata of the stored code, including its checksum. Nodes which don't serve the CodeInfo
+// query are queried for the code instead, which includes its bytecode
+func (c *Client) CodeInfo(ctx context.Context, codeID uint64) (*wasm.CodeInfoResponse, error) {
+	conn := c.grpcConn()
+	info, err := wasm.QueryCodeInfo(ctx, conn, &wasm.QueryCodeInfoRequest{CodeId: codeID})
+	if err == nil {
+		codeInfo := &wasm.CodeInfoResponse{
+			CodeID:   info.CodeID,
+			Creator:  info.Creator,
+			DataHash: info.Checksum,
+		}
+		if info.InstantiatePermission != nil {
+			codeInfo.InstantiatePermission = *info.InstantiatePermission
+		}
+		return codeInfo, nil
+	}
+	if status.Code(err) != codes.Unimplemented {
+		return nil, fmt.Errorf("failed to query code info %s", err)
+	}
+	res, err := wasm.NewQueryClient(conn).Code(ctx, &wasm.QueryCodeRequest{CodeId: codeID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query code info %s", err)
+	}
+	if res.CodeInfoResponse == nil {
+		return nil, fmt.Errorf("code %d not found", codeID)
+	}
+	return res.CodeInfoResponse, nil
+}
+
+// returns the json encoding of a contract message. Byte slices are assumed to already
+// be json encoded
+func contractMsg(msg any) ([]byte, error) {
+	var raw []byte
+	switch msg := msg.(type) {
+	case []byte:
+		raw = msg
+	case json.RawMessage:
+		raw = msg
+	default:
+		var err error
+		if raw, err = json.Marshal(msg); err != nil {
+			return nil, fmt.Errorf("failed to encode contract message %s", err)
+		}
+	}
+	if !json.Valid(raw) {
+		return nil, fmt.Errorf("contract message is not valid json")
+	}
+	return raw, nil
+}
@@ -0,0 +1,255 @@
+package compass_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/stretchr/testify/require"
+	"github.com/teamscanworks/compass"
+	"github.com/teamscanworks/compass/types/wasm"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+func TestMakeCodecWasm(t *testing.T) {
+	msgURL := sdk.MsgTypeURL(&wasm.MsgExecuteContract{})
+	_, err := compass.MakeCodec(compass.ModuleBasics, nil).InterfaceRegistry.Resolve(msgURL)
+	require.Error(t, err)
+	cdc := compass.MakeCodec(compass.ModuleBasics, []string{"wasm"})
+	resolved, err := cdc.InterfaceRegistry.Resolve(msgURL)
+	require.NoError(t, err)
+	require.IsType(t, &wasm.MsgExecuteContract{}, resolved)
+
+	sender := sdk.AccAddress([]byte("sender_address______"))
+	msg := &wasm.MsgExecuteContract{
+		Sender:   sdk.MustBech32ifyAddressBytes("cosmos", sender),
+		Contract: sdk.MustBech32ifyAddressBytes("cosmos", make([]byte, wasm.ContractAddrLen)),
+		Msg:      []byte(`{"increment":{}}`),
+		Funds:    sdk.NewCoins(sdk.NewInt64Coin("ustake", 10)),
+	}
+	signers, _, err := newSigningCodec(t, wasm.RegisterInterfaces).GetMsgV1Signers(msg)
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{sender}, signers)
+
+	packed, err := codectypes.NewAnyWithValue(msg)
+	require.NoError(t, err)
+	var unpacked sdk.Msg
+	require.NoError(t, cdc.InterfaceRegistry.UnpackAny(packed, &unpacked))
+	require.Equal(t, msg, unpacked)
+}
+
+func TestBuildContractAddressPredictable(t *testing.T) {
+	// test vector shared by wasmd and cosmjs
+	checksum, err := hex.DecodeString("13a1fc994cc6d1c81b746ee0c0ff6f90043875e0bf1d9be6b7d779fc978dc2a5")
+	require.NoError(t, err)
+	creator, err := hex.DecodeString("9999999999aaaaaaaaaabbbbbbbbbbcccccccccc")
+	require.NoError(t, err)
+	addr := wasm.BuildContractAddressPredictable(checksum, creator, []byte("a"), nil)
+	require.Equal(t, "5e865d3e45ad3e961f77fd77d46543417ced44d924dc3e079b5415ff6775f847", hex.EncodeToString(addr))
+	require.Equal(t,
+		"purple1t6r960j945lfv8mhl4mage2rg97w63xeynwrupum2s2l7em4lprs9ce5hk",
+		sdk.MustBech32ifyAddressBytes("purple", addr),
+	)
+
+	withMsg := wasm.BuildContractAddressPredictable(checksum, creator, []byte("a"), []byte(`{}`))
+	require.NotEqual(t, addr, withMsg)
+}
+
+// serves wasm queries, and the CodeInfo query only if `codeInfo` is set
+type wasmService struct {
+	wasm.UnimplementedQueryServer
+	codeInfo *wasm.QueryCodeInfoResponse
+	code     *wasm.QueryCodeResponse
+	// number of Code queries served
+	codeQueries int
+	// data of the smart queries served
+	smartQueries []string
+}
+
+func (s *wasmService) Code(context.Context, *wasm.QueryCodeRequest) (*wasm.QueryCodeResponse, error) {
+	s.codeQueries++
+	return s.code, nil
+}
+
+func (s *wasmService) SmartContractState(_ context.Context, req *wasm.QuerySmartContractStateRequest) (*wasm.QuerySmartContractStateResponse, error) {
+	s.smartQueries = append(s.smartQueries, string(req.QueryData))
+	return &wasm.QuerySmartContractStateResponse{Data: []byte(`{"count":3}`)}, nil
+}
+
+func (s *wasmService) register(srv *grpc.Server) {
+	if s.codeInfo == nil {
+		wasm.RegisterQueryServer(srv, s)
+		return
+	}
+	// the generated service doesn't include the CodeInfo query, so it is served alongside the queries used
+	srv.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "cosmwasm.wasm.v1.Query",
+		HandlerType: (*any)(nil),
+		Methods: []grpc.MethodDesc{
+			unaryMethod("CodeInfo", func(context.Context, *wasm.QueryCodeInfoRequest) (*wasm.QueryCodeInfoResponse, error) {
+				return s.codeInfo, nil
+			}),
+			unaryMethod("Code", s.Code),
+			unaryMethod("SmartContractState", s.SmartContractState),
+		},
+	}, s)
+}
+
+// returns the description of a unary grpc method served by the function
+func unaryMethod[Req, Res any](name string, fn func(context.Context, *Req) (*Res, error)) grpc.MethodDesc {
+	return grpc.MethodDesc{
+		MethodName: name,
+		Handler: func(_ any, ctx context.Context, dec func(any) error, _ grpc.UnaryServerInterceptor) (any, error) {
+			req := new(Req)
+			if err := dec(req); err != nil {
+				return nil, err
+			}
+			return fn(ctx, req)
+		},
+	}
+}
+
+// returns a client with the wasm codec, signing with a key funded for fees
+func newWasmClient(t *testing.T, wasmQueries *wasmService) (*compass.Client, *fakeNode) {
+	node := newFakeNode(t, "testing")
+	cfg := newTestConfig(node.srv.URL, newNodeInfoServer(t, "testing", wasmQueries.register, func(srv *grpc.Server) {
+		authtypes.RegisterQueryServer(srv, &accountService{sequence: 5})
+		banktypes.RegisterQueryServer(srv, &balanceService{balances: sdk.NewCoins(sdk.NewInt64Coin("stake", 1_000_000))})
+	}))
+	cfg.ExtraCodecs = []string{"wasm"}
+	client, err := compass.NewClient(zap.NewNop(), cfg, []keyring.Option{compass.DefaultSignatureOptions()})
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close(context.Background()) })
+	_, err = client.AddKey("default", 118)
+	require.NoError(t, err)
+	require.NoError(t, client.SetFromAddress())
+	return client, node
+}
+
+// returns the result of a transaction responding to its only message with `resp`
+func msgResponseResult(t *testing.T, resp proto.Message) abci.ExecTxResult {
+	packed, err := codectypes.NewAnyWithValue(resp)
+	require.NoError(t, err)
+	data, err := proto.Marshal(&sdk.TxMsgData{MsgResponses: []*codectypes.Any{packed}})
+	require.NoError(t, err)
+	return abci.ExecTxResult{Data: data}
+}
+
+func TestStoreCode(t *testing.T) {
+	client, node := newWasmClient(t, &wasmService{})
+	ctx := context.Background()
+	node.broadcastResults = map[int]abci.ExecTxResult{
+		0: msgResponseResult(t, &wasm.MsgStoreCodeResponse{CodeID: 7, Checksum: []byte("checksum")}),
+		1: msgResponseResult(t, &wasm.MsgStoreCodeResponse{CodeID: 8}),
+	}
+
+	// uncompressed code is gzip compressed before being uploaded
+	code := []byte("\x00asm\x01\x00\x00\x00")
+	res, err := client.StoreCode(ctx, code, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), res.CodeID)
+	require.Equal(t, []byte("checksum"), res.Checksum)
+	decoded, err := client.DecodeTx(node.broadcasts[0])
+	require.NoError(t, err)
+	uploaded := decoded.Msgs[0].Msg.(*wasm.MsgStoreCode).WASMByteCode
+	zr, err := gzip.NewReader(bytes.NewReader(uploaded))
+	require.NoError(t, err)
+	uncompressed, err := io.ReadAll(zr)
+	require.NoError(t, err)
+	require.Equal(t, code, uncompressed)
+
+	// compressed code is uploaded as is
+	res, err = client.StoreCode(ctx, uploaded, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(8), res.CodeID)
+	decoded, err = client.DecodeTx(node.broadcasts[1])
+	require.NoError(t, err)
+	require.Equal(t, uploaded, decoded.Msgs[0].Msg.(*wasm.MsgStoreCode).WASMByteCode)
+}
+
+func TestContractMessages(t *testing.T) {
+	wasmQueries := &wasmService{}
+	client, node := newWasmClient(t, wasmQueries)
+	ctx := context.Background()
+	contract := sdk.MustBech32ifyAddressBytes("cosmos", make([]byte, wasm.ContractAddrLen))
+	node.broadcastResults = map[int]abci.ExecTxResult{0: msgResponseResult(t, &wasm.MsgExecuteContractResponse{Data: []byte("done")})}
+
+	// messages which aren't valid json are rejected before broadcasting
+	_, err := client.ExecuteContract(ctx, contract, []byte(`{"increment":`), nil)
+	require.ErrorContains(t, err, "not valid json")
+	_, err = client.ExecuteContract(ctx, contract, func() {}, nil)
+	require.ErrorContains(t, err, "failed to encode contract message")
+	require.Empty(t, node.broadcasts)
+
+	funds := sdk.NewCoins(sdk.NewInt64Coin("stake", 10))
+	res, err := client.ExecuteContract(ctx, contract, map[string]any{"increment": map[string]any{}}, funds)
+	require.NoError(t, err)
+	require.Equal(t, []byte("done"), res.Data)
+	decoded, err := client.DecodeTx(node.broadcasts[0])
+	require.NoError(t, err)
+	execute := decoded.Msgs[0].Msg.(*wasm.MsgExecuteContract)
+	require.Equal(t, client.FromAddress(), execute.Sender)
+	require.Equal(t, contract, execute.Contract)
+	require.JSONEq(t, `{"increment":{}}`, string(execute.Msg))
+	require.Equal(t, funds, execute.Funds)
+
+	var result struct {
+		Count int `json:"count"`
+	}
+	require.ErrorContains(t, client.QueryContractSmart(ctx, contract, json.RawMessage(`{"get_count"`), &result), "not valid json")
+	require.Empty(t, wasmQueries.smartQueries)
+	require.NoError(t, client.QueryContractSmart(ctx, contract, map[string]any{"get_count": map[string]any{}}, &result))
+	require.Equal(t, 3, result.Count)
+	require.Equal(t, []string{`{"get_count":{}}`}, wasmQueries.smartQueries)
+}
+
+func TestCodeInfo(t *testing.T) {
+	checksum, err := hex.DecodeString("13a1fc994cc6d1c81b746ee0c0ff6f90043875e0bf1d9be6b7d779fc978dc2a5")
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// the code info is queried without downloading the code
+	wasmQueries := &wasmService{codeInfo: &wasm.QueryCodeInfoResponse{
+		CodeID:                3,
+		Creator:               "cosmos1creator",
+		Checksum:              checksum,
+		InstantiatePermission: &wasm.AccessConfig{Permission: wasm.AccessTypeEverybody},
+	}}
+	client, _ := newWasmClient(t, wasmQueries)
+	info, err := client.CodeInfo(ctx, 3)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), info.CodeID)
+	require.Equal(t, "cosmos1creator", info.Creator)
+	require.Equal(t, checksum, []byte(info.DataHash))
+	require.Equal(t, wasm.AccessTypeEverybody, info.InstantiatePermission.Permission)
+
+	creator, err := hex.DecodeString("9999999999aaaaaaaaaabbbbbbbbbbcccccccccc")
+	require.NoError(t, err)
+	addr, err := client.PredictContractAddress(ctx, 3, sdk.MustBech32ifyAddressBytes("cosmos", creator), []byte("a"), nil, false)
+	require.NoError(t, err)
+	require.Equal(t, sdk.MustBech32ifyAddressBytes("cosmos", wasm.BuildContractAddressPredictable(checksum, creator, []byte("a"), nil)), addr)
+	require.Zero(t, wasmQueries.codeQueries)
+
+	// nodes without the CodeInfo query are queried for the code
+	wasmQueries = &wasmService{code: &wasm.QueryCodeResponse{
+		CodeInfoResponse: &wasm.CodeInfoResponse{CodeID: 3, DataHash: checksum},
+		Data:             []byte("code"),
+	}}
+	client, _ = newWasmClient(t, wasmQueries)
+	info, err = client.CodeInfo(ctx, 3)
+	require.NoError(t, err)
+	require.Equal(t, checksum, []byte(info.DataHash))
+	require.Equal(t, 1, wasmQueries.codeQueries)
+}